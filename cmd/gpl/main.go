@@ -10,7 +10,6 @@ import (
 	"log"
 	"os"
 	"runtime"
-	"sort"
 	"strings"
 	"text/template"
 
@@ -37,16 +36,11 @@ func newauto() *auto {
 // if position is zero, then i should save iter index and then add string to result
 // ... and offset is for whatever last item is in newLine? or seems like pos should always equal line length
 // and RetSegment from above makes it look like fuzzy match just doesn't work ..
-//
-// maybe sort so prefixed stuff shows first?
 func (a auto) Do(line []rune, pos int) (newLine [][]rune, offset int) {
 	ln := string(line[:pos])
 	pos = 0
 	p, n := a.Match(string(ln), 0.33)
-
-	sort.Slice(p, func(i, j int) bool {
-		return !strings.HasPrefix(p[j], ln) && strings.HasPrefix(p[i], ln)
-	})
+	p, n = trigram.Ranker{Parser: a.Parser(), Weight: 0.5, PrefixBoost: 1}.Rank(ln, p, n)
 
 	for i, s := range p {
 		t := strings.TrimPrefix(s, ln)
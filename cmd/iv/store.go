@@ -408,21 +408,21 @@ func (st *Store) Init(i int) (err error) {
 
 func monitor(r *cycle.R, load func(int), signal, die, done chan struct{}) {
 	var dead bool
-	loader := func(i int) {
+	loader := func(i int) bool {
 		select {
 		case <-die:
 			dead = true
 		default:
 		}
 		if dead {
-			return
+			return false
 		}
 		load(i)
+		return true
 	}
 
-	i := r.Index()
-	r.Do(i, 1, loader)
-	r.Do(i-1, -1, loader)
+	// load nearest first, favoring direction of travel.
+	r.Near(2, loader)
 
 	for {
 		if dead {
@@ -432,9 +432,7 @@ func monitor(r *cycle.R, load func(int), signal, die, done chan struct{}) {
 
 		select {
 		case <-signal:
-			i := r.Index()
-			r.Do(i, 1, loader)
-			r.Do(i-1, -1, loader)
+			r.Near(2, loader)
 		case <-die:
 			close(done)
 			return
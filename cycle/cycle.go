@@ -11,6 +11,7 @@ type R struct {
 	o pro // set projection
 	n int // subset length
 	z int // zero index displacement; if o.l == index-z, index args of zero map to zero
+	s int // sign of stride of last cycle
 }
 
 // New instance of R with displacement, index, subset length, and parent set length.
@@ -44,6 +45,12 @@ func (r *R) Map(i int) int {
 	return pmod(dl-r.z, r.n)
 }
 
+// In reports whether parent index i is within projection.
+func (r *R) In(i int) bool {
+	dl, _ := r.o.diff(pmod(i, r.o.n))
+	return dl < r.n
+}
+
 // Cycle projection and index, return offset index along stride.
 func (r *R) Cycle(sp, si int) (i, s int, err error) {
 	o := r.o // copy
@@ -61,9 +68,119 @@ func (r *R) Cycle(sp, si int) (i, s int, err error) {
 
 	r.o = o   // replace
 	r.z -= sp // displace
+
+	if si != 0 {
+		r.s = sign(si)
+	} else if sp != 0 {
+		r.s = sign(sp)
+	}
 	return i, s, nil
 }
 
+// Direction returns sign of index stride of last Cycle, or of projection stride if index
+// stride was zero; returns zero if never cycled.
+func (r *R) Direction() int { return r.s }
+
+// Near executes fn for each index of projection in order of distance from index, such as
+// i, i+1, i-1, i+2, i-2, and so on, until fn returns false. Indices in Direction, or to the
+// right if zero, are first among indices of equal distance. If w is greater than one,
+// distance of indices opposite Direction is multiplied by w, such that w indices ahead
+// are visited for each index behind.
+func (r *R) Near(w float64, fn func(i int) bool) {
+	if w < 1 {
+		w = 1
+	}
+	dir := r.s
+	if dir == 0 {
+		dir = 1
+	}
+	// count of indices ahead and behind index within projection.
+	dl, _ := r.o.diff(r.o.i)
+	na, nb := r.n-1-dl, dl
+	if dir < 0 {
+		na, nb = nb, na
+	}
+	if !fn(r.o.i) {
+		return
+	}
+	for a, b := 1, 1; a <= na || b <= nb; {
+		var i int
+		if a <= na && (b > nb || float64(a) <= w*float64(b)) {
+			i, a = r.o.i+dir*a, a+1
+		} else {
+			i, b = r.o.i-dir*b, b+1
+		}
+		if !fn(pmod(i, r.o.n)) {
+			return
+		}
+	}
+}
+
+// Insert k parent indices before parent index i, from zero to parent set length, shifting
+// indices from i by k. Index remains on the same element at the same distance from left
+// projection, so its subset index is unchanged; projection length and displacement are unchanged.
+func (r *R) Insert(i, k int) error {
+	if i < 0 || i > r.o.n || k < 0 {
+		return fmt.Errorf("insert %v indices at %v out of range for set length %v", k, i, r.o.n)
+	}
+	shift := func(x int) int {
+		if x >= i {
+			return x + k
+		}
+		return x
+	}
+	dl, _ := r.o.diff(r.o.i)
+	return r.reset(shift(r.o.i), dl, r.n, r.o.n+k)
+}
+
+// Remove k parent indices from parent index i, shifting indices from i+k by -k. If removed,
+// index moves to the element following those removed, otherwise index remains on the same
+// element; index remains at the same distance from left projection, so its subset index is
+// unchanged. Parent set length must remain at least subset length.
+func (r *R) Remove(i, k int) error {
+	if i < 0 || k < 0 || i+k > r.o.n {
+		return fmt.Errorf("remove %v indices at %v out of range for set length %v", k, i, r.o.n)
+	}
+	if r.o.n-k < r.n || r.o.n-k == 0 {
+		return fmt.Errorf("remove %v indices leaves set length %v less than subset length %v", k, r.o.n-k, r.n)
+	}
+	x := r.o.i
+	if x >= i+k {
+		x -= k
+	} else if x >= i {
+		x = i
+	}
+	dl, _ := r.o.diff(r.o.i)
+	return r.reset(pmod(x, r.o.n-k), dl, r.n, r.o.n-k)
+}
+
+// Resize subset length to n, from one to parent set length. Left projection is unchanged
+// unless index would be beyond right projection, in which case index becomes rightmost
+// of projection. Displacement is unchanged.
+func (r *R) Resize(n int) error {
+	if n <= 0 || n > r.o.n {
+		return fmt.Errorf("resize subset length to %v out of range for set length %v", n, r.o.n)
+	}
+	dl, _ := r.o.diff(r.o.i)
+	if dl >= n {
+		dl = n - 1
+	}
+	return r.reset(r.o.i, dl, n, r.o.n)
+}
+
+// reset projection for index i at distance dl from left projection, with subset
+// length n and parent set length nset.
+func (r *R) reset(i, dl, n, nset int) error {
+	o := pro{i: i, n: nset}
+	o.l = pmod(i-dl, nset)
+	o.r = pmod(o.l+n, nset)
+	if err := o.verify(); err != nil {
+		return err
+	}
+	r.o, r.n = o, n
+	return nil
+}
+
 // Do executes fn for each index along stride to projection end.
 // If stride is zero, fn(index) is called once.
 func (r *R) Do(i, s int, fn func(i int)) {
@@ -83,6 +200,26 @@ func (r *R) Do(i, s int, fn func(i int)) {
 	}
 }
 
+// leaving returns indices of projection of r not within projection of q, in order of
+// stride s from the left if positive or from the right if negative.
+func (r *R) leaving(q *R, s int) (is []int) {
+	for k := 0; k < r.n; k++ {
+		if i := r.at(k, s); !q.In(i) {
+			is = append(is, i)
+		}
+	}
+	return is
+}
+
+// at returns parent index k steps into projection from the left if s is positive,
+// or from the right if negative.
+func (r *R) at(k, s int) int {
+	if s < 0 {
+		return pmod(r.o.l+r.n-1-k, r.o.n)
+	}
+	return pmod(r.o.l+k, r.o.n)
+}
+
 type pro struct{ l, i, r, n int }
 
 // verify non-strict totality.
@@ -101,6 +238,16 @@ func (o pro) diff(i int) (dl, dr int) {
 	return pmod(o.n+i-o.l, o.n), pmod(o.n-i+o.r, o.n)
 }
 
+// sign returns -1 if x is negative, 1 if positive, or 0.
+func sign(x int) int {
+	if x < 0 {
+		return -1
+	} else if x > 0 {
+		return 1
+	}
+	return 0
+}
+
 // pmod returns positive modulo for inputs.
 func pmod(x, n int) int { return (x%n + n) % n }
 
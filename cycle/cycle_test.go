@@ -1,6 +1,8 @@
 package cycle
 
 import (
+	"fmt"
+	"math/rand"
 	"reflect"
 	"strings"
 	"testing"
@@ -155,3 +157,185 @@ func TestVerify(t *testing.T) {
 		}
 	}
 }
+
+// checkMap fails t if Map of projection indices is not a bijection onto subset indices,
+// or index is not within projection.
+func checkMap(t *testing.T, r *R) {
+	t.Helper()
+	seen := make([]bool, r.n)
+	var n int
+	for i := 0; i < r.o.n; i++ {
+		if !r.In(i) {
+			continue
+		}
+		n++
+		if k := r.Map(i); k < 0 || k >= r.n || seen[k] {
+			t.Fatalf("Map(%v) = %v not a bijection for %+v n=%v z=%v", i, k, r.o, r.n, r.z)
+		} else {
+			seen[k] = true
+		}
+	}
+	if n != r.n || !r.In(r.Index()) {
+		t.Fatalf("projection of %v indices with index %v for %+v n=%v", n, r.Index(), r.o, r.n)
+	}
+	if err := r.o.verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for run := 0; run < 50; run++ {
+		// ids are identities of elements of parent set.
+		var ids []int
+		next := 0
+		nset := 1 + rnd.Intn(20)
+		for ; next < nset; next++ {
+			ids = append(ids, next)
+		}
+		n := 1 + rnd.Intn(nset)
+		z := -rnd.Intn(nset)
+		r, err := New(z, pmod(z+rnd.Intn(n), nset), n, nset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkMap(t, r)
+
+		for step := 0; step < 100; step++ {
+			cur, slot, z, left := ids[r.Index()], r.Map(r.Index()), r.z, r.Left()
+			switch rnd.Intn(4) {
+			case 0:
+				i, k := rnd.Intn(len(ids)+1), rnd.Intn(4)
+				if err := r.Insert(i, k); err != nil {
+					t.Fatal(err)
+				}
+				var add []int
+				for ; k > 0; k, next = k-1, next+1 {
+					add = append(add, next)
+				}
+				ids = append(ids[:i], append(add, ids[i:]...)...)
+			case 1:
+				i := rnd.Intn(len(ids))
+				k := rnd.Intn(len(ids) - i + 1)
+				err := r.Remove(i, k)
+				if len(ids)-k < r.n || len(ids)-k == 0 {
+					if err == nil {
+						t.Fatalf("Remove(%v, %v) of %v with subset length %v did not fail", i, k, len(ids), r.n)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				wasRemoved := false
+				for _, x := range ids[i : i+k] {
+					wasRemoved = wasRemoved || x == cur
+				}
+				ids = append(ids[:i], ids[i+k:]...)
+				if wasRemoved {
+					cur = ids[i%len(ids)]
+				}
+			case 2:
+				n := 1 + rnd.Intn(len(ids))
+				dl, _ := r.Diff(r.Index())
+				if err := r.Resize(n); err != nil {
+					t.Fatal(err)
+				}
+				if dl < n && r.Left() != left {
+					t.Fatalf("Resize(%v) moved left projection from %v to %v", n, left, r.Left())
+				}
+				checkMap(t, r)
+				if ids[r.Index()] != cur {
+					t.Fatalf("Resize(%v) moved index", n)
+				}
+				continue
+			case 3:
+				sp := rnd.Intn(2*r.n+1) - r.n
+				if _, _, err := r.Cycle(sp, sp); err != nil {
+					t.Fatal(err)
+				}
+				checkMap(t, r)
+				continue
+			}
+			checkMap(t, r)
+			if r.o.n != len(ids) {
+				t.Fatalf("parent set length %v, want %v", r.o.n, len(ids))
+			}
+			if ids[r.Index()] != cur || r.Map(r.Index()) != slot || r.z != z {
+				t.Fatalf("index element %v slot %v z %v, want %v %v %v", ids[r.Index()], r.Map(r.Index()), r.z, cur, slot, z)
+			}
+		}
+	}
+
+	r, _ := New(0, 0, 3, 5)
+	if r.Insert(6, 1) == nil || r.Remove(4, 2) == nil || r.Remove(0, 3) == nil || r.Resize(0) == nil || r.Resize(6) == nil {
+		t.Fatal("expected errors for arguments out of range")
+	}
+}
+
+func TestNear(t *testing.T) {
+	near := func(r *R, w float64) (is []int) {
+		r.Near(w, func(i int) bool {
+			is = append(is, i)
+			return true
+		})
+		return is
+	}
+
+	r, err := New(-3, 0, 7, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := fmt.Sprint(near(r, 0)), "[0 1 9 2 8 3 7]"; have != want {
+		t.Fatalf("Near: have %v, want %v", have, want)
+	}
+	if have, want := fmt.Sprint(near(r, 2)), "[0 1 2 9 3 8 7]"; have != want {
+		t.Fatalf("Near weighted: have %v, want %v", have, want)
+	}
+
+	if _, _, err := r.Cycle(0, -1); err != nil {
+		t.Fatal(err)
+	}
+	if r.Direction() != -1 {
+		t.Fatalf("Direction: have %v, want -1", r.Direction())
+	}
+	if have, want := fmt.Sprint(near(r, 1)), "[9 8 0 7 1 2 3]"; have != want {
+		t.Fatalf("Near after Cycle: have %v, want %v", have, want)
+	}
+	if have, want := fmt.Sprint(near(r, 3)), "[9 8 7 0 1 2 3]"; have != want {
+		t.Fatalf("Near weighted after Cycle: have %v, want %v", have, want)
+	}
+
+	var n int
+	r.Near(1, func(i int) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Fatalf("Near did not stop, called %v times", n)
+	}
+
+	// every index of projection visited once for any index and weight.
+	for _, nset := range []int{1, 5, 12} {
+		for ns := 1; ns <= nset; ns++ {
+			for k := 0; k < ns; k++ {
+				r, err := New(2, 2+k, ns, nset)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, w := range []float64{1, 1.5, 4} {
+					seen := make(map[int]bool)
+					for _, i := range near(r, w) {
+						if !r.In(i) || seen[i] {
+							t.Fatalf("Near visited %v twice or outside projection", i)
+						}
+						seen[i] = true
+					}
+					if len(seen) != ns {
+						t.Fatalf("Near visited %v indices, want %v", len(seen), ns)
+					}
+				}
+			}
+		}
+	}
+}
@@ -78,3 +78,34 @@ func Example() {
 	// index 1
 	// cycle [0][1][2]
 }
+
+func ExampleWindow() {
+	// data of entire set is loaded by index; one item to the left and right of index are kept.
+	r, err := cycle.New(-1, 0, 3, 5)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := cycle.NewWindow(r,
+		func(i int) string { return fmt.Sprintf("item%v", i) },
+		func(i int, v string) { fmt.Println("evict", i, v) },
+	)
+	show := func() {
+		var vs []string
+		w.Do(func(i int, v string) { vs = append(vs, v) })
+		fmt.Println(vs)
+	}
+	show()
+
+	// cycle the projection and index to right.
+	evicted, entered, err := w.Cycle(1, 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("evicted", evicted, "entered", entered)
+	show()
+	// Output:
+	// [item4 item0 item1]
+	// evict 4 item4
+	// evicted [4] entered [2]
+	// [item0 item1 item2]
+}
@@ -0,0 +1,111 @@
+package cycle
+
+import "fmt"
+
+// Grid is a cyclic relation of multiple dimensions, such as a window sliding over a toroidal
+// grid, with an R for each axis. Subset indices of grid cells are in row-major order of axes.
+type Grid struct {
+	rs []*R
+}
+
+// NewGrid returns Grid of an R for each axis.
+func NewGrid(rs ...*R) *Grid { return &Grid{rs: rs} }
+
+// Dims returns number of axes.
+func (g *Grid) Dims() int { return len(g.rs) }
+
+// Axis returns R of axis k.
+func (g *Grid) Axis(k int) *R { return g.rs[k] }
+
+// Len returns number of cells of projection, product of subset lengths of each axis.
+func (g *Grid) Len() int {
+	n := 1
+	for _, r := range g.rs {
+		n *= r.n
+	}
+	return n
+}
+
+// Index returns absolute index of parent set of each axis.
+func (g *Grid) Index() []int {
+	p := make([]int, len(g.rs))
+	for k, r := range g.rs {
+		p[k] = r.Index()
+	}
+	return p
+}
+
+// In reports whether cell at parent indices p, one for each axis, is within projection.
+func (g *Grid) In(p ...int) bool {
+	for k, r := range g.rs {
+		if !r.In(p[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Map a cell at parent indices p, one for each axis, to subset index.
+func (g *Grid) Map(p ...int) int {
+	var m int
+	for k, r := range g.rs {
+		m = m*r.n + r.Map(pmod(p[k], r.o.n))
+	}
+	return m
+}
+
+// Do executes fn for each cell of projection in row-major order from left projection of
+// each axis; p is reused between calls.
+func (g *Grid) Do(fn func(p []int)) {
+	if len(g.rs) == 0 {
+		return
+	}
+	p := make([]int, len(g.rs))
+	ks := make([]int, len(g.rs))
+	for k, r := range g.rs {
+		p[k] = r.o.l
+	}
+	for {
+		fn(p)
+		k := len(g.rs) - 1
+		for ; k >= 0; k-- {
+			r := g.rs[k]
+			if ks[k]++; ks[k] < r.n {
+				p[k] = pmod(p[k]+1, r.o.n)
+				break
+			}
+			ks[k], p[k] = 0, r.o.l
+		}
+		if k < 0 {
+			return
+		}
+	}
+}
+
+// Cycle projection and index of each axis k by sp[k] and si[k] as R.Cycle; returns parent
+// indices of each axis leaving and entering projection in order of stride. Cells leaving are
+// those of any index leaving on any axis; likewise for entering. If any axis fails to cycle,
+// no axis is cycled.
+func (g *Grid) Cycle(sp, si []int) (leaving, entering [][]int, err error) {
+	if len(sp) != len(g.rs) || len(si) != len(g.rs) {
+		return nil, nil, fmt.Errorf("cycle of %v and %v strides for %v axes", len(sp), len(si), len(g.rs))
+	}
+	olds := make([]R, len(g.rs))
+	for k, r := range g.rs {
+		olds[k] = *r
+	}
+	for k, r := range g.rs {
+		if _, _, err := r.Cycle(sp[k], si[k]); err != nil {
+			for j := range olds[:k] {
+				*g.rs[j] = olds[j]
+			}
+			return nil, nil, fmt.Errorf("axis %v: %w", k, err)
+		}
+	}
+	leaving, entering = make([][]int, len(g.rs)), make([][]int, len(g.rs))
+	for k, r := range g.rs {
+		leaving[k] = olds[k].leaving(r, sp[k])
+		entering[k] = r.leaving(&olds[k], sp[k])
+	}
+	return leaving, entering, nil
+}
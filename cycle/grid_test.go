@@ -0,0 +1,103 @@
+package cycle
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestGrid(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, dims := range [][2][]int{
+		{{3, 4}, {6, 8}},       // subset and parent set lengths of each axis
+		{{2, 3, 2}, {5, 6, 7}}, // three dimensions
+		{{4, 1}, {4, 9}},       // full axis
+		{{2, 2}, {3, 5}},       // strides that wrap
+	} {
+		n, nset := dims[0], dims[1]
+		var rs []*R
+		for k := range n {
+			r, err := New(0, 0, n[k], nset[k])
+			if err != nil {
+				t.Fatal(err)
+			}
+			rs = append(rs, r)
+		}
+		g := NewGrid(rs...)
+
+		for step := 0; step < 200; step++ {
+			seen := make([]bool, g.Len())
+			cells := make(map[string]int)
+			g.Do(func(p []int) {
+				if !g.In(p...) {
+					t.Fatalf("%v: cell %v not in projection", n, p)
+				}
+				m := g.Map(p...)
+				if m < 0 || m >= g.Len() || seen[m] {
+					t.Fatalf("%v: Map(%v) = %v not a bijection", n, p, m)
+				}
+				seen[m] = true
+				cells[fmt.Sprint(p)] = m
+			})
+			if len(cells) != g.Len() {
+				t.Fatalf("%v: Do visited %v cells, want %v", n, len(cells), g.Len())
+			}
+
+			sp, si := make([]int, len(n)), make([]int, len(n))
+			for k := range sp {
+				sp[k] = rnd.Intn(2*n[k]+1) - n[k]
+				si[k] = sp[k]
+			}
+			in := make([][]bool, len(n))
+			for k, r := range rs {
+				in[k] = make([]bool, nset[k])
+				for i := range in[k] {
+					in[k][i] = r.In(i)
+				}
+			}
+			leaving, entering, err := g.Cycle(sp, si)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, r := range rs {
+				var wl, we int
+				for i := range in[k] {
+					if in[k][i] && !r.In(i) {
+						wl++
+					}
+					if !in[k][i] && r.In(i) {
+						we++
+					}
+				}
+				if len(leaving[k]) != wl || len(entering[k]) != we {
+					t.Fatalf("%v: axis %v leaving %v entering %v, want %v and %v", n, k, leaving[k], entering[k], wl, we)
+				}
+				for _, i := range leaving[k] {
+					if !in[k][i] || r.In(i) {
+						t.Fatalf("%v: axis %v index %v not leaving", n, k, i)
+					}
+				}
+				for _, i := range entering[k] {
+					if in[k][i] || !r.In(i) {
+						t.Fatalf("%v: axis %v index %v not entering", n, k, i)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGridCycleError(t *testing.T) {
+	r0, _ := New(0, 0, 2, 4)
+	r1, _ := New(0, 0, 2, 4)
+	g := NewGrid(r0, r1)
+	if _, _, err := g.Cycle([]int{1, 0}, []int{1, 3}); err == nil {
+		t.Fatal("expected error for index beyond projection")
+	}
+	if p := g.Index(); fmt.Sprint(p) != "[0 0]" || r0.Left() != 0 {
+		t.Fatalf("failed Cycle modified grid, index %v left %v", p, r0.Left())
+	}
+	if _, _, err := g.Cycle([]int{1}, []int{1}); err == nil {
+		t.Fatal("expected error for stride count")
+	}
+}
@@ -0,0 +1,199 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrEvicted is returned when waiting on an index not within projection, or that left
+	// projection while waiting.
+	ErrEvicted = errors.New("cycle: index not within projection")
+
+	// ErrClosed is returned when cycling a closed Loader.
+	ErrClosed = errors.New("cycle: loader closed")
+)
+
+// Loader loads elements of each index of a projection in the background with a bounded
+// number of workers, nearest to index first, canceling loads of indices that leave the
+// projection on Cycle. Loader owns its R; use Loader's methods rather than cycling R directly.
+type Loader[T any] struct {
+	mu      sync.Mutex
+	cond    sync.Cond
+	w       *Window[*entry[T]]
+	pending []*entry[T] // in order of loading
+	closed  bool
+
+	load   func(ctx context.Context, i int) (T, error)
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// state of an entry.
+const (
+	pending = iota
+	loading
+	done
+)
+
+// entry of element of parent index i.
+type entry[T any] struct {
+	i      int
+	v      T
+	err    error
+	state  int
+	cancel context.CancelFunc // set while loading
+	done   chan struct{}      // closed when done
+}
+
+// NewLoader returns loader of projection of r with n workers calling load for each index,
+// where load must return early when its context is done. Loader is closed when ctx is done.
+func NewLoader[T any](ctx context.Context, r *R, n int, load func(ctx context.Context, i int) (T, error)) *Loader[T] {
+	l := &Loader[T]{load: load}
+	l.cond.L = &l.mu
+	l.ctx, l.cancel = context.WithCancel(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.w = NewWindow(r, func(i int) *entry[T] {
+		return &entry[T]{i: i, done: make(chan struct{})}
+	}, func(i int, e *entry[T]) {
+		e.finish(ErrEvicted)
+	})
+	l.schedule()
+
+	l.wg.Add(n)
+	for ; n > 0; n-- {
+		go l.work()
+	}
+	go func() {
+		<-l.ctx.Done()
+		l.Close()
+	}()
+	return l
+}
+
+// Index returns absolute index of parent set.
+func (l *Loader[T]) Index() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Index()
+}
+
+// Cycle projection and index as R.Cycle, canceling loads of indices leaving projection
+// and scheduling loads of those entering; returns parent indices evicted and entered,
+// or ErrClosed if closed.
+func (l *Loader[T]) Cycle(sp, si int) (evicted, entered []int, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, nil, ErrClosed
+	}
+	if evicted, entered, err = l.w.Cycle(sp, si); err != nil {
+		return nil, nil, err
+	}
+	l.schedule()
+	return evicted, entered, nil
+}
+
+// Get blocks until element of parent index i is loaded; returns element and error of load,
+// or ErrEvicted if i is not within projection or leaves it while waiting. Loading of i is
+// moved ahead of other pending indices.
+func (l *Loader[T]) Get(ctx context.Context, i int) (v T, err error) {
+	l.mu.Lock()
+	if !l.w.In(i) {
+		l.mu.Unlock()
+		return v, ErrEvicted
+	}
+	e := l.w.Get(i)
+	if e.state == pending {
+		for k, x := range l.pending {
+			if x == e {
+				copy(l.pending[1:k+1], l.pending[:k])
+				l.pending[0] = e
+				break
+			}
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-e.done:
+		return e.v, e.err
+	case <-ctx.Done():
+		return v, ctx.Err()
+	}
+}
+
+// Close cancels all loads and waits for workers to return; elements not loaded are
+// finished with context.Canceled.
+func (l *Loader[T]) Close() {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		l.cancel()
+		l.w.Do(func(i int, e *entry[T]) { e.finish(context.Canceled) })
+		l.pending = nil
+		l.cond.Broadcast()
+	}
+	l.mu.Unlock()
+	l.wg.Wait()
+}
+
+// schedule pending entries nearest to index first, favoring direction of last cycle.
+func (l *Loader[T]) schedule() {
+	l.pending = l.pending[:0]
+	l.w.Near(2, func(i int) bool {
+		if e := l.w.Get(i); e.state == pending {
+			l.pending = append(l.pending, e)
+		}
+		return true
+	})
+	l.cond.Broadcast()
+}
+
+// work loads pending entries until closed.
+func (l *Loader[T]) work() {
+	defer l.wg.Done()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for {
+		for len(l.pending) == 0 && !l.closed {
+			l.cond.Wait()
+		}
+		if l.closed {
+			return
+		}
+		e := l.pending[0]
+		l.pending = l.pending[1:]
+		if e.state != pending {
+			continue
+		}
+		ctx, cancel := context.WithCancel(l.ctx)
+		e.state, e.cancel = loading, cancel
+
+		l.mu.Unlock()
+		v, err := l.load(ctx, e.i)
+		cancel()
+		l.mu.Lock()
+
+		if e.state == loading {
+			e.v = v
+			e.finish(err)
+		}
+	}
+}
+
+// finish entry with err, canceling load if loading.
+func (e *entry[T]) finish(err error) {
+	if e.state == done {
+		return
+	}
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.state, e.err = done, err
+	close(e.done)
+}
@@ -0,0 +1,166 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoader(t *testing.T) {
+	r, err := New(-3, 0, 7, N)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers = 2
+	var mu sync.Mutex
+	var active, peak int
+	l := NewLoader(context.Background(), r, workers, func(ctx context.Context, i int) (int, error) {
+		mu.Lock()
+		active++
+		if active > peak {
+			peak = active
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return i + 1, nil
+	})
+	defer l.Close()
+
+	for step := 0; step < 20; step++ {
+		var want []int
+		r.Do(r.Left(), 1, func(i int) { want = append(want, i) })
+		for _, i := range want {
+			v, err := l.Get(context.Background(), i)
+			if err != nil {
+				t.Fatalf("step %v: Get(%v): %v", step, i, err)
+			}
+			if v != i+1 {
+				t.Fatalf("step %v: Get(%v): have %v, want %v", step, i, v, i+1)
+			}
+		}
+		if _, err := l.Get(context.Background(), r.Right()); err != ErrEvicted {
+			t.Fatalf("step %v: Get(%v) outside projection: have %v, want %v", step, r.Right(), err, ErrEvicted)
+		}
+		if _, _, err := l.Cycle(1-step%3, 1-step%3); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if peak > workers {
+		t.Fatalf("have %v concurrent loads, want at most %v", peak, workers)
+	}
+}
+
+func TestLoaderCycleAll(t *testing.T) {
+	for nset := 1; nset <= 6; nset++ {
+		for n := 1; n <= nset; n++ {
+			for sp := -nset; sp <= nset; sp++ {
+				r, err := New(0, 0, n, nset)
+				if err != nil {
+					t.Fatal(err)
+				}
+				l := NewLoader(context.Background(), r, 2, func(ctx context.Context, i int) (int, error) {
+					return i + 1, nil
+				})
+				for step := 0; step < 3; step++ {
+					if _, _, err := l.Cycle(sp, sp); err != nil {
+						t.Fatal(err)
+					}
+					for i := 0; i < nset; i++ {
+						if !r.In(i) {
+							continue
+						}
+						if v, err := l.Get(context.Background(), i); err != nil || v != i+1 {
+							t.Fatalf("nset=%v n=%v sp=%v step=%v: Get(%v): have %v %v, want %v", nset, n, sp, step, i, v, err, i+1)
+						}
+					}
+				}
+				l.Close()
+			}
+		}
+	}
+}
+
+func TestLoaderEvict(t *testing.T) {
+	r, err := New(0, 0, 3, N)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan int, N)
+	canceled := make(chan int, N)
+	l := NewLoader(context.Background(), r, 3, func(ctx context.Context, i int) (int, error) {
+		started <- i
+		<-ctx.Done()
+		canceled <- i
+		return 0, ctx.Err()
+	})
+	defer l.Close()
+
+	for k := 0; k < 3; k++ {
+		<-started
+	}
+	done := make(chan error)
+	go func() {
+		_, err := l.Get(context.Background(), 0)
+		done <- err
+	}()
+
+	evicted, _, err := l.Cycle(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evicted) != 1 || evicted[0] != 0 {
+		t.Fatalf("have evicted %v, want [0]", evicted)
+	}
+	if i := <-canceled; i != 0 {
+		t.Fatalf("have canceled %v, want 0", i)
+	}
+	if err := <-done; err != ErrEvicted {
+		t.Fatalf("Get of evicted: have %v, want %v", err, ErrEvicted)
+	}
+	if i := <-started; i != 3 {
+		t.Fatalf("have started %v, want 3", i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := l.Get(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get with deadline: have %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	l.Close()
+	if _, err := l.Get(context.Background(), 2); err != context.Canceled {
+		t.Fatalf("Get after Close: have %v, want %v", err, context.Canceled)
+	}
+	if _, _, err := l.Cycle(1, 1); err != ErrClosed {
+		t.Fatalf("Cycle after Close: have %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestLoaderContext(t *testing.T) {
+	r, err := New(0, 0, 4, N)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	l := NewLoader(ctx, r, 1, func(ctx context.Context, i int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	cancel()
+	for i := 0; i < 4; i++ {
+		if _, err := l.Get(context.Background(), i); err != context.Canceled {
+			t.Fatalf("Get(%v): have %v, want %v", i, err, context.Canceled)
+		}
+	}
+	l.Close()
+}
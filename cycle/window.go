@@ -0,0 +1,141 @@
+package cycle
+
+// Window holds an element for each index of a projection of R, loading elements of
+// indices that enter the projection and evicting those that leave it on Cycle.
+type Window[T any] struct {
+	*R
+	vs []T
+
+	buf  []T    // scratch elements swapped with vs on update
+	kept []bool // scratch of subset indices kept on update
+
+	load  func(i int) T
+	evict func(i int, v T)
+}
+
+// NewWindow returns window of r calling load for each index of the projection; load
+// returns element of parent index i and evict, if not nil, is called with parent index
+// and element of each index leaving the projection. If load is nil, elements are zero
+// until set.
+func NewWindow[T any](r *R, load func(i int) T, evict func(i int, v T)) *Window[T] {
+	w := &Window[T]{R: r, vs: make([]T, r.n), load: load, evict: evict}
+	if load != nil {
+		w.Do(func(i int, _ T) { w.vs[r.Map(i)] = load(i) })
+	}
+	return w
+}
+
+// Get returns element of parent index i, or zero value if not within projection.
+func (w *Window[T]) Get(i int) (v T) {
+	if w.In(i) {
+		v = w.vs[w.Map(i)]
+	}
+	return v
+}
+
+// Set element of parent index i; returns false if not within projection.
+func (w *Window[T]) Set(i int, v T) bool {
+	if !w.In(i) {
+		return false
+	}
+	w.vs[w.Map(i)] = v
+	return true
+}
+
+// Do executes fn for each parent index and element of projection from left to right.
+func (w *Window[T]) Do(fn func(i int, v T)) {
+	for k := 0; k < w.n; k++ {
+		i := pmod(w.o.l+k, w.o.n)
+		fn(i, w.vs[w.Map(i)])
+	}
+}
+
+// Cycle projection and index as R.Cycle, evicting elements of indices leaving the
+// projection and loading those entering; returns parent indices evicted and entered
+// in order of stride.
+func (w *Window[T]) Cycle(sp, si int) (evicted, entered []int, err error) {
+	return w.update(sp, func() error {
+		_, _, err := w.R.Cycle(sp, si)
+		return err
+	}, func(x int) (int, bool) {
+		return x, true
+	})
+}
+
+// Insert k parent indices before parent index i as R.Insert, moving elements to their
+// subset index and evicting and loading elements of indices leaving and entering projection;
+// returns parent indices evicted, as before insert, and entered from left to right.
+func (w *Window[T]) Insert(i, k int) (evicted, entered []int, err error) {
+	return w.update(1, func() error { return w.R.Insert(i, k) }, func(x int) (int, bool) {
+		if x >= i {
+			return x + k, true
+		}
+		return x, true
+	})
+}
+
+// Remove k parent indices from parent index i as R.Remove, evicting elements of removed
+// indices as with Insert.
+func (w *Window[T]) Remove(i, k int) (evicted, entered []int, err error) {
+	return w.update(1, func() error { return w.R.Remove(i, k) }, func(x int) (int, bool) {
+		if x >= i+k {
+			return x - k, true
+		}
+		return x, x < i
+	})
+}
+
+// Resize subset length to n as R.Resize, evicting and loading elements as with Insert.
+func (w *Window[T]) Resize(n int) (evicted, entered []int, err error) {
+	return w.update(1, func() error { return w.R.Resize(n) }, func(x int) (int, bool) {
+		return x, true
+	})
+}
+
+// update R with fn and move each element to the parent index given by f of its prior
+// index, if ok and within projection; elements not moved are evicted and remaining
+// indices of projection loaded, in order of stride s from the left if positive or
+// from the right if negative.
+func (w *Window[T]) update(s int, fn func() error, f func(x int) (int, bool)) (evicted, entered []int, err error) {
+	old, vs := *w.R, w.vs
+	if err := fn(); err != nil {
+		return nil, nil, err
+	}
+	if cap(w.buf) < w.n {
+		w.buf = make([]T, w.n)
+	}
+	if cap(w.kept) < w.n {
+		w.kept = make([]bool, w.n)
+	}
+	w.vs, w.buf = w.buf[:w.n], vs
+	kept := w.kept[:w.n]
+	for k := range kept {
+		kept[k] = false
+	}
+	for k := 0; k < old.n; k++ {
+		x := old.at(k, s)
+		v := vs[old.Map(x)]
+		if j, ok := f(x); ok && w.In(j) {
+			w.vs[w.Map(j)], kept[w.Map(j)] = v, true
+		} else {
+			evicted = append(evicted, x)
+			if w.evict != nil {
+				w.evict(x, v)
+			}
+		}
+	}
+	for k := 0; k < w.n; k++ {
+		j := w.at(k, s)
+		if !kept[w.Map(j)] {
+			entered = append(entered, j)
+			if w.load != nil {
+				w.vs[w.Map(j)] = w.load(j)
+			}
+		}
+	}
+	var zero T
+	for k := range w.buf {
+		w.buf[k] = zero // release elements no longer held
+	}
+	return evicted, entered, nil
+}
@@ -0,0 +1,216 @@
+package cycle
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestWindow(t *testing.T) {
+	for _, n := range []int{1, 5, 7, N} {
+		r, err := New(-n/2, 0, n, N)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded := make(map[int]bool)
+		w := NewWindow(r, func(i int) int {
+			if loaded[i] {
+				t.Fatalf("n=%v: load(%v) while loaded", n, i)
+			}
+			loaded[i] = true
+			return i + 1
+		}, func(i, v int) {
+			if !loaded[i] || v != i+1 {
+				t.Fatalf("n=%v: evict(%v, %v) not loaded", n, i, v)
+			}
+			delete(loaded, i)
+		})
+
+		for step := 0; step < 200; step++ {
+			var want []int
+			r.Do(r.Left(), 1, func(i int) { want = append(want, i) })
+			if n == N {
+				want = want[:0]
+				for i := 0; i < N; i++ {
+					want = append(want, (r.Left()+i)%N)
+				}
+			}
+			var have []int
+			w.Do(func(i, v int) {
+				if v != i+1 {
+					t.Fatalf("n=%v: element of %v: have %v, want %v", n, i, v, i+1)
+				}
+				have = append(have, i)
+			})
+			if fmt.Sprint(have) != fmt.Sprint(want) || len(loaded) != n {
+				t.Fatalf("n=%v: projection have %v, want %v, loaded %v", n, have, want, loaded)
+			}
+			for i := 0; i < N; i++ {
+				if r.In(i) != loaded[i] {
+					t.Fatalf("n=%v: In(%v) is %v", n, i, r.In(i))
+				}
+				if v := w.Get(i); (v != 0) != loaded[i] {
+					t.Fatalf("n=%v: Get(%v) is %v", n, i, v)
+				}
+			}
+
+			sp := rand.Intn(2*n+1) - n
+			before := make(map[int]bool)
+			for i := range loaded {
+				before[i] = true
+			}
+			evicted, entered, err := w.Cycle(sp, sp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, i := range evicted {
+				if !before[i] || loaded[i] {
+					t.Fatalf("n=%v sp=%v: evicted %v not leaving", n, sp, i)
+				}
+			}
+			for _, i := range entered {
+				if before[i] || !loaded[i] {
+					t.Fatalf("n=%v sp=%v: entered %v not entering", n, sp, i)
+				}
+			}
+			if len(evicted) != len(entered) {
+				t.Fatalf("n=%v sp=%v: evicted %v, entered %v", n, sp, evicted, entered)
+			}
+			if len(entered) > 1 && sp != 0 {
+				if d := entered[1] - entered[0]; pmod(d, N) != pmod(sp/absInt(sp), N) {
+					t.Fatalf("n=%v sp=%v: entered %v not in order of stride", n, sp, entered)
+				}
+			}
+		}
+	}
+}
+
+func TestWindowCycleAll(t *testing.T) {
+	for nset := 1; nset <= 8; nset++ {
+		for n := 1; n <= nset; n++ {
+			for sp := -nset; sp <= nset; sp++ {
+				r, err := New(0, 0, n, nset)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// owner of each element loaded, by order of load.
+				var owner []int
+				w := NewWindow(r, func(i int) int {
+					owner = append(owner, i)
+					return len(owner) - 1
+				}, nil)
+				for step := 0; step < 3; step++ {
+					if _, _, err := w.Cycle(sp, sp); err != nil {
+						t.Fatal(err)
+					}
+					for i := 0; i < nset; i++ {
+						if w.In(i) && owner[w.Get(i)] != i {
+							t.Fatalf("nset=%v n=%v sp=%v step=%v: Get(%v) returned element of %v", nset, n, sp, step, i, owner[w.Get(i)])
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestWindowSet(t *testing.T) {
+	r, err := New(0, 0, 3, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWindow[string](r, nil, nil)
+	if !w.Set(2, "two") || w.Set(3, "three") {
+		t.Fatal("Set reported wrong result")
+	}
+	if w.Get(2) != "two" || w.Get(3) != "" {
+		t.Fatalf("Get: have %q %q", w.Get(2), w.Get(3))
+	}
+	evicted, entered, err := w.Cycle(-1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(evicted, entered) != "[2] [9]" || w.Get(2) != "" {
+		t.Fatalf("Cycle: have %v %v and %q", evicted, entered, w.Get(2))
+	}
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestWindowResize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	ids := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	next := len(ids)
+	prev := ids
+
+	r, err := New(-2, 0, 5, len(ids))
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := make(map[int]bool)
+	w := NewWindow(r, func(i int) int {
+		loaded[ids[i]] = true
+		return ids[i]
+	}, func(i, v int) {
+		if prev[i] != v || !loaded[v] {
+			t.Fatalf("evict(%v, %v) of %v", i, v, prev[i])
+		}
+		delete(loaded, v)
+	})
+
+	for step := 0; step < 500; step++ {
+		prev = append([]int(nil), ids...)
+		var err error
+		switch rnd.Intn(3) {
+		case 0:
+			i, k := rnd.Intn(len(ids)+1), rnd.Intn(3)
+			var add []int
+			for j := 0; j < k; j, next = j+1, next+1 {
+				add = append(add, next)
+			}
+			ids = append(ids[:i:i], append(add, ids[i:]...)...)
+			_, _, err = w.Insert(i, k)
+		case 1:
+			i := rnd.Intn(len(ids))
+			k := rnd.Intn(len(ids) - i + 1)
+			if len(ids)-k < r.n || len(ids)-k == 0 {
+				continue
+			}
+			ids = append(ids[:i:i], ids[i+k:]...)
+			_, _, err = w.Remove(i, k)
+		case 2:
+			_, _, err = w.Resize(1 + rnd.Intn(len(ids)))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(loaded) != r.n {
+			t.Fatalf("loaded %v elements for subset length %v", len(loaded), r.n)
+		}
+		w.Do(func(i, v int) {
+			if v != ids[i] {
+				t.Fatalf("element of %v: have %v, want %v", i, v, ids[i])
+			}
+		})
+	}
+}
+
+func BenchmarkWindowCycle(b *testing.B) {
+	r, err := New(0, 0, 256, 1024)
+	if err != nil {
+		b.Fatal(err)
+	}
+	w := NewWindow(r, func(i int) int { return i }, nil)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if _, _, err := w.Cycle(1, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
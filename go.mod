@@ -12,7 +12,8 @@ require (
 	golang.org/x/exp/shiny v0.0.0-20221019170559-20944726eadf
 	golang.org/x/image v0.1.0
 	golang.org/x/mobile v0.0.0-20221020085226-b36e6246172e
-	golang.org/x/sys v0.1.0
+	golang.org/x/sys v0.5.0
+	golang.org/x/text v0.13.0
 	gonum.org/v1/plot v0.0.0-20180905080458-5f3c436ce602
 )
 
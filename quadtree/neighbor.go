@@ -0,0 +1,196 @@
+package quadtree
+
+import "sort"
+
+// Direction of a neighbor, where up and left are towards the origin.
+type Direction int
+
+// Directions in clockwise order.
+const (
+	Up Direction = iota
+	UpRight
+	Right
+	DownRight
+	Down
+	DownLeft
+	Left
+	UpLeft
+)
+
+// Offset returns unit step of direction along each axis.
+func (d Direction) Offset() (dx, dy int) {
+	switch d {
+	case Up:
+		return 0, -1
+	case UpRight:
+		return 1, -1
+	case Right:
+		return 1, 0
+	case DownRight:
+		return 1, 1
+	case Down:
+		return 0, 1
+	case DownLeft:
+		return -1, 1
+	case Left:
+		return -1, 0
+	case UpLeft:
+		return -1, -1
+	}
+	return 0, 0
+}
+
+// Neighbor returns node of same level adjacent to key in direction d, or false if
+// key is on the boundary facing d.
+func Neighbor(key uint32, d Direction) (uint32, bool) { return codec32.neighbor(key, d) }
+
+// LargerNeighbor returns node in keys of same or lesser level containing the neighbor of
+// key in direction d, or false if none. Keys must be sorted with Sort.
+func LargerNeighbor(keys []uint32, key uint32, d Direction) (uint32, bool) {
+	return codec32.larger(keys, key, d)
+}
+
+// SmallerNeighbors returns nodes in keys of greater level within the neighbor of key in
+// direction d that are adjacent to key, in sorted order. Keys must be sorted with Sort.
+func SmallerNeighbors(keys []uint32, key uint32, d Direction) []uint32 {
+	return codec32.smaller(keys, key, d)
+}
+
+// Sort orders keys along the z-order curve with parents preceding children.
+func Sort(keys []uint32) { codec32.sort(keys) }
+
+// Neighbor64 returns node of same level adjacent to key in direction d, or false if
+// key is on the boundary facing d.
+func Neighbor64(key uint64, d Direction) (uint64, bool) { return codec64.neighbor(key, d) }
+
+// LargerNeighbor64 returns node in keys of same or lesser level containing the neighbor of
+// key in direction d, or false if none. Keys must be sorted with Sort64.
+func LargerNeighbor64(keys []uint64, key uint64, d Direction) (uint64, bool) {
+	return codec64.larger(keys, key, d)
+}
+
+// SmallerNeighbors64 returns nodes in keys of greater level within the neighbor of key in
+// direction d that are adjacent to key, in sorted order. Keys must be sorted with Sort64.
+func SmallerNeighbors64(keys []uint64, key uint64, d Direction) []uint64 {
+	return codec64.smaller(keys, key, d)
+}
+
+// Sort64 orders keys along the z-order curve with parents preceding children.
+func Sort64(keys []uint64) { codec64.sort(keys) }
+
+// codec of keys of type K.
+type codec[K uint32 | uint64] struct {
+	max    uint32 // deepest level
+	encode func(x, y, level uint32) K
+	decode func(key K) (x, y, level uint32)
+}
+
+var (
+	codec32 = codec[uint32]{MaxLevel, Encode, Decode}
+	codec64 = codec[uint64]{MaxLevel64, Encode64, Decode64}
+)
+
+func (c codec[K]) neighbor(key K, d Direction) (K, bool) {
+	x, y, level := c.decode(key)
+	dx, dy := d.Offset()
+	nx, ny := int64(x)+int64(dx), int64(y)+int64(dy)
+	if n := int64(1) << level; nx < 0 || ny < 0 || nx >= n || ny >= n {
+		return 0, false
+	}
+	return c.encode(uint32(nx), uint32(ny), level), true
+}
+
+func (c codec[K]) larger(keys []K, key K, d Direction) (K, bool) {
+	n, ok := c.neighbor(key, d)
+	if !ok {
+		return 0, false
+	}
+	x, y, level := c.decode(n)
+	for {
+		if a := c.encode(x, y, level); c.contains(keys, a) {
+			return a, true
+		}
+		if level == 0 {
+			return 0, false
+		}
+		x, y, level = x>>1, y>>1, level-1
+	}
+}
+
+func (c codec[K]) smaller(keys []K, key K, d Direction) []K {
+	n, ok := c.neighbor(key, d)
+	if !ok {
+		return nil
+	}
+	dx, dy := d.Offset()
+	var nodes []K
+	var walk func(n K)
+	walk = func(n K) {
+		x, y, level := c.decode(n)
+		if level == c.max || !c.descends(keys, n) {
+			return
+		}
+		for cy := uint32(0); cy < 2; cy++ {
+			for cx := uint32(0); cx < 2; cx++ {
+				// children of neighbor on the side facing key.
+				if (dx > 0 && cx == 1) || (dx < 0 && cx == 0) || (dy > 0 && cy == 1) || (dy < 0 && cy == 0) {
+					continue
+				}
+				child := c.encode(x<<1|cx, y<<1|cy, level+1)
+				if c.contains(keys, child) {
+					nodes = append(nodes, child)
+				} else {
+					walk(child)
+				}
+			}
+		}
+	}
+	walk(n)
+	c.sort(nodes)
+	return nodes
+}
+
+// z returns position of key at deepest level.
+func (c codec[K]) z(key K) K {
+	x, y, level := c.decode(key)
+	s := c.max - level
+	return c.encode(x<<s, y<<s, c.max)
+}
+
+func (c codec[K]) less(a, b K) bool {
+	if za, zb := c.z(a), c.z(b); za != zb {
+		return za < zb
+	}
+	_, _, la := c.decode(a)
+	_, _, lb := c.decode(b)
+	return la < lb
+}
+
+func (c codec[K]) sort(keys []K) {
+	sort.Slice(keys, func(i, j int) bool { return c.less(keys[i], keys[j]) })
+}
+
+// search returns index of first key not less than key.
+func (c codec[K]) search(keys []K, key K) int {
+	return sort.Search(len(keys), func(i int) bool { return !c.less(keys[i], key) })
+}
+
+func (c codec[K]) contains(keys []K, key K) bool {
+	i := c.search(keys, key)
+	return i < len(keys) && keys[i] == key
+}
+
+// descends reports whether any of keys is a child of key at any depth; as children
+// immediately follow key in order, only the next key following key is tested.
+func (c codec[K]) descends(keys []K, key K) bool {
+	i := c.search(keys, key)
+	if i < len(keys) && keys[i] == key {
+		i++
+	}
+	if i == len(keys) {
+		return false
+	}
+	x, y, level := c.decode(key)
+	kx, ky, kl := c.decode(keys[i])
+	return kl > level && kx>>(kl-level) == x && ky>>(kl-level) == y
+}
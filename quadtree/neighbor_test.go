@@ -0,0 +1,137 @@
+package quadtree
+
+import (
+	"fmt"
+	"testing"
+)
+
+var directions = []Direction{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+
+func TestNeighbor(t *testing.T) {
+	const lvl = 3
+	var nodes []uint32
+	Split(0, lvl, &nodes)
+	for _, key := range nodes {
+		x, y, _ := Decode(key)
+		for _, d := range directions {
+			dx, dy := d.Offset()
+			nx, ny := int(x)+dx, int(y)+dy
+			inside := nx >= 0 && ny >= 0 && nx < 1<<lvl && ny < 1<<lvl
+
+			n, ok := Neighbor(key, d)
+			if ok != inside {
+				t.Fatalf("Neighbor(%v, %v, %v): have ok %v, want %v", x, y, d, ok, inside)
+			}
+			n64, ok64 := Neighbor64(Encode64(x, y, lvl), d)
+			if ok64 != inside {
+				t.Fatalf("Neighbor64(%v, %v, %v): have ok %v, want %v", x, y, d, ok64, inside)
+			}
+			if !inside {
+				continue
+			}
+			if want := Encode(uint32(nx), uint32(ny), lvl); n != want {
+				t.Fatalf("Neighbor(%v, %v, %v): have %b, want %b", x, y, d, n, want)
+			}
+			if want := Encode64(uint32(nx), uint32(ny), lvl); n64 != want {
+				t.Fatalf("Neighbor64(%v, %v, %v): have %b, want %b", x, y, d, n64, want)
+			}
+		}
+	}
+
+	if _, ok := Neighbor(0, Right); ok {
+		t.Fatal("Neighbor of root: have ok true, want false")
+	}
+}
+
+// cell of key in units of level 4.
+type cell struct{ x0, y0, x1, y1, level int }
+
+func cellOf(key uint64) cell {
+	x, y, level := Decode64(key)
+	s := 1 << (4 - level)
+	return cell{int(x) * s, int(y) * s, int(x+1) * s, int(y+1) * s, int(level)}
+}
+
+func (a cell) within(b cell) bool {
+	return a.x0 >= b.x0 && a.y0 >= b.y0 && a.x1 <= b.x1 && a.y1 <= b.y1
+}
+
+func TestLargerSmallerNeighbors(t *testing.T) {
+	// leaves of level 2 grid with a few cells subdivided to levels 3 and 4.
+	var keys []uint64
+	var grid []uint64
+	Split64(0, 2, &grid)
+	for i, key := range grid {
+		if i%3 == 0 {
+			var sub []uint64
+			Split64(key, 3, &sub)
+			for j, k := range sub {
+				if j == i%4 {
+					Split64(k, 4, &keys)
+				} else {
+					keys = append(keys, k)
+				}
+			}
+		} else {
+			keys = append(keys, key)
+		}
+	}
+	Sort64(keys)
+
+	keys32 := make([]uint32, len(keys))
+	for i, k := range keys {
+		x, y, level := Decode64(k)
+		keys32[i] = Encode(x, y, level)
+	}
+	Sort(keys32)
+
+	for _, key := range keys {
+		c := cellOf(key)
+		for _, d := range directions {
+			dx, dy := d.Offset()
+			n, ok := Neighbor64(key, d)
+
+			var larger []uint64
+			var smaller []uint64
+			if ok {
+				nc := cellOf(n)
+				for _, k := range keys {
+					kc := cellOf(k)
+					if kc.level <= c.level && nc.within(kc) {
+						larger = append(larger, k)
+					}
+					if kc.level > c.level && kc.within(nc) &&
+						(dx <= 0 || kc.x0 == nc.x0) && (dx >= 0 || kc.x1 == nc.x1) &&
+						(dy <= 0 || kc.y0 == nc.y0) && (dy >= 0 || kc.y1 == nc.y1) {
+						smaller = append(smaller, k)
+					}
+				}
+			}
+
+			l, ok := LargerNeighbor64(keys, key, d)
+			if have, want := fmt.Sprint(ok, l), fmt.Sprint(len(larger) == 1, first(larger)); have != want {
+				t.Fatalf("LargerNeighbor64(%+v, %v): have %v, want %v", c, d, have, want)
+			}
+			if have, want := fmt.Sprint(SmallerNeighbors64(keys, key, d)), fmt.Sprint(smaller); have != want {
+				t.Fatalf("SmallerNeighbors64(%+v, %v): have %v, want %v", c, d, have, want)
+			}
+
+			x, y, level := Decode64(key)
+			key32 := Encode(x, y, level)
+			l32, ok32 := LargerNeighbor(keys32, key32, d)
+			if ok32 != ok || (ok && uint64(l32) != uint64(Encode(Decode64(l)))) {
+				t.Fatalf("LargerNeighbor(%+v, %v): have %v %b, want %v %b", c, d, ok32, l32, ok, l)
+			}
+			if have, want := len(SmallerNeighbors(keys32, key32, d)), len(smaller); have != want {
+				t.Fatalf("SmallerNeighbors(%+v, %v): have %v nodes, want %v", c, d, have, want)
+			}
+		}
+	}
+}
+
+func first(a []uint64) uint64 {
+	if len(a) == 0 {
+		return 0
+	}
+	return a[0]
+}
@@ -3,6 +3,18 @@ package quadtree
 
 import "math"
 
+// MaxLevel is the deepest level of a uint32 key.
+const MaxLevel = 14
+
+// Dilate expands the lower 16 bits of word with a zero bit using shift-or algorithm.
+func Dilate(x uint32) uint32 {
+	x &= 0x0000FFFF
+	x = (x | (x << 8)) & 0x00FF00FF
+	x = (x | (x << 4)) & 0x0F0F0F0F
+	x = (x | (x << 2)) & 0x33333333
+	return (x | (x << 1)) & 0x55555555
+}
+
 // Undilate deinterleaves word using shift-or algorithm.
 func Undilate(x uint32) uint32 {
 	x = (x | (x >> 1)) & 0x33333333
@@ -20,6 +32,12 @@ func Decode(key uint32) (x, y, level uint32) {
 	return
 }
 
+// Encode returns word of column major position and level; x and y must be less than 1<<level,
+// and level must not exceed MaxLevel.
+func Encode(x, y, level uint32) uint32 {
+	return Dilate(x)<<4 | Dilate(y)<<5 | level&0xF
+}
+
 // Children generates nodes from a quadtree encoded word.
 func Children(key uint32) (uint32, uint32, uint32, uint32) {
 	key = ((key + 1) & 0xF) | ((key & 0xFFFFFFF0) << 2)
@@ -60,6 +78,25 @@ func Cell(key uint32) (nx, ny, size float32) {
 	return
 }
 
+// Key returns word of cell at level containing normalized coordinates; coordinates
+// outside [0, 1) are clamped to the nearest cell.
+func Key(nx, ny float32, level uint32) uint32 {
+	size := float32(uint32(1 << level))
+	return Encode(clamp(nx*size, level), clamp(ny*size, level), level)
+}
+
+// clamp returns floor of v within [0, 1<<level).
+func clamp[T float32 | float64](v T, level uint32) uint32 {
+	n := uint64(1) << level
+	switch {
+	case !(v >= 0): // includes NaN
+		return 0
+	case v >= T(n):
+		return uint32(n - 1)
+	}
+	return uint32(v)
+}
+
 // Cap calculates the required capacity to hold all nodes of a given level.
 func Cap(lvl int) int {
 	return int(math.Pow(4, float64(lvl)))
@@ -0,0 +1,99 @@
+package quadtree
+
+// Keys of type uint64 hold level in the five least significant bits followed by
+// interleaved position, x occupying even bits and y odd bits, for levels up to MaxLevel64.
+
+// MaxLevel64 is the deepest level of a uint64 key.
+const MaxLevel64 = 29
+
+// Dilate32 expands the bits of a uint32 with a zero bit.
+func Dilate32(x uint32) uint64 {
+	n := uint64(x)
+	n = (n ^ n<<16) & 0x0000ffff0000ffff
+	n = (n ^ n<<8) & 0x00ff00ff00ff00ff
+	n = (n ^ n<<4) & 0x0f0f0f0f0f0f0f0f
+	n = (n ^ n<<2) & 0x3333333333333333
+	return (n ^ n<<1) & 0x5555555555555555
+}
+
+// Undilate32 constricts the bits of a uint64 removing every bit starting with
+// the second least significant bit and every other bit there-after.
+func Undilate32(x uint64) uint32 {
+	n := x & 0x5555555555555555
+	n = (n ^ n>>1) & 0x3333333333333333
+	n = (n ^ n>>2) & 0x0f0f0f0f0f0f0f0f
+	n = (n ^ n>>4) & 0x00ff00ff00ff00ff
+	n = (n ^ n>>8) & 0x0000ffff0000ffff
+	return uint32(n ^ n>>16)
+}
+
+// Encode64 returns key of column major position and level; x and y must be less than 1<<level,
+// and level must not exceed MaxLevel64.
+func Encode64(x, y, level uint32) uint64 {
+	return Dilate32(x)<<5 | Dilate32(y)<<6 | uint64(level&0x1F)
+}
+
+// Decode64 retrieves column major position and level from key.
+func Decode64(key uint64) (x, y, level uint32) {
+	return Undilate32(key >> 5), Undilate32(key >> 6), uint32(key & 0x1F)
+}
+
+// Children64 generates nodes from a quadtree encoded key.
+func Children64(key uint64) (uint64, uint64, uint64, uint64) {
+	key = ((key + 1) & 0x1F) | ((key &^ 0x1F) << 2)
+	return key, key | 0x20, key | 0x40, key | 0x60
+}
+
+// Parent64 generates node from quadtree encoded key.
+func Parent64(key uint64) uint64 {
+	return ((key - 1) & 0x1F) | ((key >> 2) &^ 0x1F)
+}
+
+// IsUpperLeft64 determines if node represents the upper-left child of its parent.
+func IsUpperLeft64(key uint64) bool {
+	return ((key & 0x60) == 0x00)
+}
+
+// IsUpperRight64 determines if node represents the upper-right child of its parent.
+func IsUpperRight64(key uint64) bool {
+	return ((key & 0x60) == 0x20)
+}
+
+// IsLowerLeft64 determines if node represents the lower-left child of its parent.
+func IsLowerLeft64(key uint64) bool {
+	return ((key & 0x60) == 0x40)
+}
+
+// IsLowerRight64 determines if node represents the lower-right child of its parent.
+func IsLowerRight64(key uint64) bool {
+	return ((key & 0x60) == 0x60)
+}
+
+// Cell64 retrieves normalized coordinates and size.
+func Cell64(key uint64) (nx, ny, size float64) {
+	x, y, level := Decode64(key)
+	size = 1 / float64(uint64(1)<<level)
+	nx = float64(x) * size
+	ny = float64(y) * size
+	return
+}
+
+// Key64 returns key of cell at level containing normalized coordinates; coordinates
+// outside [0, 1) are clamped to the nearest cell.
+func Key64(nx, ny float64, level uint32) uint64 {
+	size := float64(uint64(1) << level)
+	return Encode64(clamp(nx*size, level), clamp(ny*size, level), level)
+}
+
+// Split64 recursively collects children at the given level into nodes pointer.
+func Split64(key uint64, lvl int, nodes *[]uint64) {
+	if key&0x1F == uint64(lvl) {
+		*nodes = append(*nodes, key)
+	} else {
+		a, b, c, d := Children64(key)
+		Split64(a, lvl, nodes)
+		Split64(b, lvl, nodes)
+		Split64(c, lvl, nodes)
+		Split64(d, lvl, nodes)
+	}
+}
@@ -3,6 +3,7 @@ package quadtree
 import (
 	"fmt"
 	"testing"
+	"testing/quick"
 )
 
 func printWord(t *testing.T, pad string, title string, key uint32) {
@@ -69,3 +70,93 @@ func BenchmarkParent(b *testing.B) {
 		_ = Parent(a)
 	}
 }
+
+func TestEncode(t *testing.T) {
+	f := func(x, y uint16) bool {
+		key := Encode(uint32(x)>>2, uint32(y)>>2, MaxLevel)
+		a, b, lvl := Decode(key)
+		return a == uint32(x)>>2 && b == uint32(y)>>2 && lvl == MaxLevel && Undilate(Dilate(uint32(x))) == uint32(x)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1 << 12}); err != nil {
+		t.Fatal(err)
+	}
+
+	var nodes []uint32
+	Split(0, 4, &nodes)
+	for _, key := range nodes {
+		x, y, lvl := Decode(key)
+		if have := Encode(x, y, lvl); have != key {
+			t.Fatalf("Encode(%v, %v, %v): have %b, want %b", x, y, lvl, have, key)
+		}
+		nx, ny, size := Cell(key)
+		if have := Key(nx+size/2, ny+size/2, lvl); have != key {
+			t.Fatalf("Key(%v, %v, %v): have %b, want %b", nx, ny, lvl, have, key)
+		}
+	}
+}
+
+func TestEncode64(t *testing.T) {
+	f := func(x, y uint32) bool {
+		x, y = x>>3, y>>3
+		key := Encode64(x, y, MaxLevel64)
+		a, b, lvl := Decode64(key)
+		return a == x && b == y && lvl == MaxLevel64 && Undilate32(Dilate32(x)) == x
+	}
+	if m := uint32(1<<MaxLevel64 - 1); !f(m<<3, m<<3) {
+		t.Fatalf("sanity check: failed on input %0X", m)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1 << 16}); err != nil {
+		t.Fatal(err)
+	}
+
+	key := uint64(0)
+	for lvl := 0; lvl < MaxLevel64; lvl++ {
+		a, b, c, d := Children64(key)
+		for i, child := range []uint64{a, b, c, d} {
+			if p := Parent64(child); p != key {
+				t.Fatalf("Parent64(%b): have %b, want %b", child, p, key)
+			}
+			x, y, l := Decode64(child)
+			px, py, _ := Decode64(key)
+			if l != uint32(lvl+1) || x != px<<1|uint32(i&1) || y != py<<1|uint32(i>>1) {
+				t.Fatalf("Decode64(%b): have %v, %v, %v", child, x, y, l)
+			}
+		}
+		if !IsUpperLeft64(a) || !IsUpperRight64(b) || !IsLowerLeft64(c) || !IsLowerRight64(d) {
+			t.Fatalf("level %v: wrong quadrant predicates", lvl+1)
+		}
+		key = d
+	}
+	if x, y, _ := Decode64(key); x != 1<<MaxLevel64-1 || y != 1<<MaxLevel64-1 {
+		t.Fatalf("deepest lower-right: have %v, %v", x, y)
+	}
+
+	var nodes []uint64
+	Split64(0, 5, &nodes)
+	if len(nodes) != Cap(5) {
+		t.Fatalf("Split64: have %v nodes, want %v", len(nodes), Cap(5))
+	}
+	for _, key := range nodes {
+		x, y, lvl := Decode64(key)
+		if have := Encode64(x, y, lvl); have != key {
+			t.Fatalf("Encode64(%v, %v, %v): have %b, want %b", x, y, lvl, have, key)
+		}
+		nx, ny, size := Cell64(key)
+		if have := Key64(nx+size/2, ny+size/2, lvl); have != key {
+			t.Fatalf("Key64(%v, %v, %v): have %b, want %b", nx, ny, lvl, have, key)
+		}
+	}
+
+	for _, tt := range []struct {
+		nx, ny float64
+		want   uint64
+	}{
+		{-1, 0, Encode64(0, 0, MaxLevel64)},
+		{1, 1, Encode64(1<<MaxLevel64-1, 1<<MaxLevel64-1, MaxLevel64)},
+		{0.5, 0.25, Encode64(1<<(MaxLevel64-1), 1<<(MaxLevel64-2), MaxLevel64)},
+	} {
+		if have := Key64(tt.nx, tt.ny, MaxLevel64); have != tt.want {
+			t.Fatalf("Key64(%v, %v): have %b, want %b", tt.nx, tt.ny, have, tt.want)
+		}
+	}
+}
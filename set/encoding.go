@@ -0,0 +1,381 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Binary format of Slice is a version byte, an element kind byte, and a uvarint count
+// followed by elements. Integers are written as the first value followed by the delta
+// of each value to its predecessor, as varints; floats are written as fixed width bits
+// in little endian; strings are each written as a uvarint length followed by bytes.
+//
+// Binary format of Chain is a version byte, an element kind byte, and a uvarint count
+// of slices followed by each slice's count and elements as given above.
+
+const encodingVersion = 1
+
+const (
+	kindInt byte = iota + 1
+	kindInt8
+	kindInt16
+	kindInt32
+	kindInt64
+	kindUint
+	kindUint8
+	kindUint16
+	kindUint32
+	kindUint64
+	kindUintptr
+	kindFloat32
+	kindFloat64
+	kindString
+)
+
+var (
+	errShortBuffer = errors.New("set: unexpected end of data")
+	errUnsorted    = errors.New("set: data is not strictly ascending")
+	errTrailing    = errors.New("set: unexpected data after end")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (a Slice[T]) MarshalBinary() ([]byte, error) {
+	b, err := appendHeader[T](nil)
+	if err != nil {
+		return nil, err
+	}
+	return a.marshal(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (a *Slice[T]) UnmarshalBinary(data []byte) error {
+	b, err := readHeader[T](data)
+	if err != nil {
+		return err
+	}
+	if b, err = a.unmarshal(b); err == nil && len(b) != 0 {
+		err = errTrailing
+	}
+	return err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (a Chain[T]) MarshalBinary() ([]byte, error) {
+	b, err := appendHeader[T](nil)
+	if err != nil {
+		return nil, err
+	}
+	b = binary.AppendUvarint(b, uint64(len(a)))
+	for _, s := range a {
+		b = s.marshal(b)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (a *Chain[T]) UnmarshalBinary(data []byte) error {
+	b, err := readHeader[T](data)
+	if err != nil {
+		return err
+	}
+	b, n, err := readCount(b)
+	if err != nil {
+		return err
+	}
+	c := make(Chain[T], n)
+	for i := range c {
+		if b, err = c[i].unmarshal(b); err != nil {
+			return err
+		}
+	}
+	if len(b) != 0 {
+		return errTrailing
+	}
+	*a = c
+	return nil
+}
+
+// marshal appends count and elements of a to b.
+func (a Slice[T]) marshal(b []byte) []byte {
+	switch a := any(a).(type) {
+	case Slice[int]:
+		return appendSigned(b, a)
+	case Slice[int8]:
+		return appendSigned(b, a)
+	case Slice[int16]:
+		return appendSigned(b, a)
+	case Slice[int32]:
+		return appendSigned(b, a)
+	case Slice[int64]:
+		return appendSigned(b, a)
+	case Slice[uint]:
+		return appendUnsigned(b, a)
+	case Slice[uint8]:
+		return appendUnsigned(b, a)
+	case Slice[uint16]:
+		return appendUnsigned(b, a)
+	case Slice[uint32]:
+		return appendUnsigned(b, a)
+	case Slice[uint64]:
+		return appendUnsigned(b, a)
+	case Slice[uintptr]:
+		return appendUnsigned(b, a)
+	case Slice[float32]:
+		b = binary.AppendUvarint(b, uint64(len(a)))
+		for _, x := range a {
+			b = binary.LittleEndian.AppendUint32(b, math.Float32bits(x))
+		}
+		return b
+	case Slice[float64]:
+		b = binary.AppendUvarint(b, uint64(len(a)))
+		for _, x := range a {
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(x))
+		}
+		return b
+	case Slice[string]:
+		b = binary.AppendUvarint(b, uint64(len(a)))
+		for _, x := range a {
+			b = binary.AppendUvarint(b, uint64(len(x)))
+			b = append(b, x...)
+		}
+		return b
+	default:
+		panic("unreachable; kind verified by header")
+	}
+}
+
+// unmarshal reads count and elements of a from b; returns remaining data.
+func (a *Slice[T]) unmarshal(b []byte) ([]byte, error) {
+	switch a := any(a).(type) {
+	case *Slice[int]:
+		return readSigned(b, a)
+	case *Slice[int8]:
+		return readSigned(b, a)
+	case *Slice[int16]:
+		return readSigned(b, a)
+	case *Slice[int32]:
+		return readSigned(b, a)
+	case *Slice[int64]:
+		return readSigned(b, a)
+	case *Slice[uint]:
+		return readUnsigned(b, a)
+	case *Slice[uint8]:
+		return readUnsigned(b, a)
+	case *Slice[uint16]:
+		return readUnsigned(b, a)
+	case *Slice[uint32]:
+		return readUnsigned(b, a)
+	case *Slice[uint64]:
+		return readUnsigned(b, a)
+	case *Slice[uintptr]:
+		return readUnsigned(b, a)
+	case *Slice[float32]:
+		return readFixed(b, a, 4, func(p []byte) float32 {
+			return math.Float32frombits(binary.LittleEndian.Uint32(p))
+		})
+	case *Slice[float64]:
+		return readFixed(b, a, 8, func(p []byte) float64 {
+			return math.Float64frombits(binary.LittleEndian.Uint64(p))
+		})
+	case *Slice[string]:
+		b, n, err := readCount(b)
+		if err != nil {
+			return nil, err
+		}
+		c := make(Slice[string], 0, n)
+		for i := 0; i < n; i++ {
+			m, k := binary.Uvarint(b)
+			if k <= 0 || m > uint64(len(b)-k) {
+				return nil, errShortBuffer
+			}
+			x := string(b[k : k+int(m)])
+			if i > 0 && c[i-1] >= x {
+				return nil, errUnsorted
+			}
+			c, b = append(c, x), b[k+int(m):]
+		}
+		*a = c
+		return b, nil
+	default:
+		panic("unreachable; kind verified by header")
+	}
+}
+
+// kindOf returns element kind of T, or zero if not supported.
+func kindOf[T constraints.Ordered]() byte {
+	switch any(*new(T)).(type) {
+	case int:
+		return kindInt
+	case int8:
+		return kindInt8
+	case int16:
+		return kindInt16
+	case int32:
+		return kindInt32
+	case int64:
+		return kindInt64
+	case uint:
+		return kindUint
+	case uint8:
+		return kindUint8
+	case uint16:
+		return kindUint16
+	case uint32:
+		return kindUint32
+	case uint64:
+		return kindUint64
+	case uintptr:
+		return kindUintptr
+	case float32:
+		return kindFloat32
+	case float64:
+		return kindFloat64
+	case string:
+		return kindString
+	}
+	return 0
+}
+
+// appendHeader appends version and element kind of T to b.
+func appendHeader[T constraints.Ordered](b []byte) ([]byte, error) {
+	kind := kindOf[T]()
+	if kind == 0 {
+		return nil, fmt.Errorf("set: binary encoding of %T not supported", *new(T))
+	}
+	return append(b, encodingVersion, kind), nil
+}
+
+// readHeader verifies version and element kind of T; returns data after header.
+func readHeader[T constraints.Ordered](b []byte) ([]byte, error) {
+	kind := kindOf[T]()
+	if kind == 0 {
+		return nil, fmt.Errorf("set: binary encoding of %T not supported", *new(T))
+	}
+	if len(b) < 2 {
+		return nil, errShortBuffer
+	}
+	if b[0] != encodingVersion {
+		return nil, fmt.Errorf("set: unsupported encoding version %v", b[0])
+	}
+	if b[1] != kind {
+		return nil, fmt.Errorf("set: encoded element kind %v does not match %T", b[1], *new(T))
+	}
+	return b[2:], nil
+}
+
+// readCount returns data after count and count, which must not exceed length of data
+// since every element takes at least one byte.
+func readCount(b []byte) ([]byte, int, error) {
+	n, k := binary.Uvarint(b)
+	if k <= 0 || n > uint64(len(b)-k) {
+		return nil, 0, errShortBuffer
+	}
+	return b[k:], int(n), nil
+}
+
+func appendSigned[T constraints.Signed](b []byte, a Slice[T]) []byte {
+	b = binary.AppendUvarint(b, uint64(len(a)))
+	for i, x := range a {
+		if i == 0 {
+			b = binary.AppendVarint(b, int64(x))
+		} else {
+			// wraps as needed since difference of ascending values always fits in uint64.
+			b = binary.AppendUvarint(b, uint64(int64(x))-uint64(int64(a[i-1])))
+		}
+	}
+	return b
+}
+
+func readSigned[T constraints.Signed](b []byte, a *Slice[T]) ([]byte, error) {
+	b, n, err := readCount(b)
+	if err != nil {
+		return nil, err
+	}
+	c := make(Slice[T], n)
+	var x int64
+	for i := range c {
+		var d uint64
+		var k int
+		if i == 0 {
+			x, k = binary.Varint(b)
+		} else {
+			d, k = binary.Uvarint(b)
+		}
+		if k <= 0 {
+			return nil, errShortBuffer
+		}
+		if i > 0 {
+			// bound is computed modulo 2^64, exact for any x.
+			if d == 0 || d > uint64(math.MaxInt64)-uint64(x) {
+				return nil, errUnsorted
+			}
+			x = int64(uint64(x) + d)
+		}
+		if c[i] = T(x); int64(c[i]) != x {
+			return nil, fmt.Errorf("set: value %v overflows %T", x, c[i])
+		}
+		b = b[k:]
+	}
+	*a = c
+	return b, nil
+}
+
+func appendUnsigned[T constraints.Unsigned](b []byte, a Slice[T]) []byte {
+	b = binary.AppendUvarint(b, uint64(len(a)))
+	for i, x := range a {
+		if i == 0 {
+			b = binary.AppendUvarint(b, uint64(x))
+		} else {
+			b = binary.AppendUvarint(b, uint64(x-a[i-1]))
+		}
+	}
+	return b
+}
+
+func readUnsigned[T constraints.Unsigned](b []byte, a *Slice[T]) ([]byte, error) {
+	b, n, err := readCount(b)
+	if err != nil {
+		return nil, err
+	}
+	c := make(Slice[T], n)
+	var x uint64
+	for i := range c {
+		d, k := binary.Uvarint(b)
+		if k <= 0 {
+			return nil, errShortBuffer
+		}
+		if i > 0 && (d == 0 || d > math.MaxUint64-x) {
+			return nil, errUnsorted
+		}
+		x += d
+		if c[i] = T(x); uint64(c[i]) != x {
+			return nil, fmt.Errorf("set: value %v overflows %T", x, c[i])
+		}
+		b = b[k:]
+	}
+	*a = c
+	return b, nil
+}
+
+func readFixed[T constraints.Float](b []byte, a *Slice[T], size int, fn func([]byte) T) ([]byte, error) {
+	b, n, err := readCount(b)
+	if err != nil {
+		return nil, err
+	}
+	if n > len(b)/size {
+		return nil, errShortBuffer
+	}
+	c := make(Slice[T], n)
+	for i := range c {
+		c[i], b = fn(b[:size]), b[size:]
+		if i > 0 && !(c[i-1] < c[i]) {
+			return nil, errUnsorted
+		}
+	}
+	*a = c
+	return b, nil
+}
@@ -0,0 +1,143 @@
+package set
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Slice[int]{}
+	_ encoding.BinaryUnmarshaler = (*Slice[int])(nil)
+	_ encoding.BinaryMarshaler   = Chain[string]{}
+	_ encoding.BinaryUnmarshaler = (*Chain[string])(nil)
+)
+
+func roundTrip(t *testing.T, a encoding.BinaryMarshaler, b encoding.BinaryUnmarshaler) {
+	t.Helper()
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(%v): %v", a, err)
+	}
+	if err := b.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary(%v): %v", a, err)
+	}
+	if have := reflect.ValueOf(b).Elem().Interface(); fmt.Sprint(have) != fmt.Sprint(a) {
+		t.Fatalf("round trip: have %v, want %v", have, a)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	roundTrip(t, Slice[int]{}, new(Slice[int]))
+	roundTrip(t, Slice[int]{math.MinInt64, -1, 0, 1, math.MaxInt64}, new(Slice[int]))
+	roundTrip(t, Slice[int8]{math.MinInt8, 0, math.MaxInt8}, new(Slice[int8]))
+	roundTrip(t, Slice[uint64]{0, 1, 300, math.MaxUint64}, new(Slice[uint64]))
+	roundTrip(t, Slice[uint8]{0, 255}, new(Slice[uint8]))
+	roundTrip(t, Slice[float64]{math.Inf(-1), -1.5, 0, 2.25, math.Inf(1)}, new(Slice[float64]))
+	roundTrip(t, Slice[float32]{-1, 1}, new(Slice[float32]))
+	roundTrip(t, FromUnsorted(uniq), new(Slice[string]))
+	roundTrip(t, Slice[string]{"", "a", "ab"}, new(Slice[string]))
+	roundTrip(t, Chain[string]{{"a", "b"}, {}, {"c"}}, new(Chain[string]))
+	roundTrip(t, Chain[uint32]{{1, 2, 3}, {1 << 30}}, new(Chain[uint32]))
+}
+
+func TestBinaryCompact(t *testing.T) {
+	a := make(Slice[uint64], 1000)
+	for i := range a {
+		a[i] = 1<<40 + uint64(i)*3
+	}
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header, count, first value and a single byte per delta.
+	if have, want := len(data), 2+2+6+len(a)-1; have != want {
+		t.Fatalf("encoded length: have %v, want %v", have, want)
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	data, _ := Slice[int64]{1, 2, 3}.MarshalBinary()
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"version", append([]byte{99}, data[1:]...)},
+		{"kind", append([]byte{data[0], kindUint64}, data[2:]...)},
+		{"short", data[:len(data)-1]},
+		{"trailing", append(data[:len(data):len(data)], 0)},
+		{"duplicate", []byte{encodingVersion, kindInt64, 2, 2, 0}},
+		{"overflow", []byte{encodingVersion, kindInt64, 2, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 2}},
+	}
+	for _, tt := range tests {
+		var a Slice[int64]
+		if err := a.UnmarshalBinary(tt.data); err == nil {
+			t.Errorf("%s: expected error, have %v", tt.name, a)
+		}
+	}
+
+	var a Slice[int8]
+	data, _ = Slice[int16]{1000}.MarshalBinary()
+	data[1] = kindInt8
+	if err := a.UnmarshalBinary(data); err == nil {
+		t.Errorf("expected overflow error, have %v", a)
+	}
+
+	type named int
+	if _, err := (Slice[named]{1}).MarshalBinary(); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
+
+func FuzzSliceUnmarshalBinary(f *testing.F) {
+	for _, a := range []Slice[int]{{}, {-5, 0, 7}, {math.MinInt64, math.MaxInt64}} {
+		data, _ := a.MarshalBinary()
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var a Slice[int]
+		if err := a.UnmarshalBinary(data); err != nil {
+			return
+		}
+		for i := 1; i < len(a); i++ {
+			if a[i-1] >= a[i] {
+				t.Fatalf("unmarshaled slice not strictly ascending: %v", a)
+			}
+		}
+		roundTrip(t, a, new(Slice[int]))
+	})
+}
+
+func FuzzChainUnmarshalBinary(f *testing.F) {
+	for _, a := range []Chain[string]{{}, {{"a", "b"}, {"c"}}} {
+		data, _ := a.MarshalBinary()
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var a Chain[string]
+		if err := a.UnmarshalBinary(data); err != nil {
+			return
+		}
+		roundTrip(t, a, new(Chain[string]))
+	})
+}
+
+func FuzzSliceRoundTrip(f *testing.F) {
+	f.Add([]byte("abc"), int64(0), uint32(7))
+	f.Fuzz(func(t *testing.T, p []byte, x int64, y uint32) {
+		var s Slice[string]
+		var a Slice[int64]
+		var b Slice[uint32]
+		for i := range p {
+			s.Insert(string(p[i:]))
+			a.Insert(x * int64(p[i]))
+			b.Insert(y + uint32(p[i]))
+		}
+		roundTrip(t, s, new(Slice[string]))
+		roundTrip(t, a, new(Slice[int64]))
+		roundTrip(t, b, new(Slice[uint32]))
+	})
+}
@@ -0,0 +1,72 @@
+package set
+
+import "sort"
+
+// SliceFunc must be sorted in ascending order according to Cmp.
+//
+// Cmp returns a negative number when a < b, a positive number when a > b,
+// and zero when a == b. Values that compare equal are not distinct.
+type SliceFunc[T any] struct {
+	Cmp    func(a, b T) int
+	Values []T
+}
+
+// Insert x in place if not exists; returns x index and true if inserted.
+func (a *SliceFunc[T]) Insert(x T) (i int, ok bool) {
+	i = a.Rank(x)
+	if ok = i == len(a.Values) || a.Cmp(a.Values[i], x) != 0; ok {
+		a.Values = upsert(a.Values, x, i, ok)
+	}
+	return
+}
+
+func (a SliceFunc[T]) Has(x T) bool { return a.Index(x) != -1 }
+
+// Remove x in place if exists; returns x index and true if removed.
+func (a *SliceFunc[T]) Remove(x T) (i int, ok bool) {
+	i = a.Rank(x)
+	if ok = i < len(a.Values) && a.Cmp(a.Values[i], x) == 0; ok {
+		a.Values = append(a.Values[:i], a.Values[i+1:]...)
+	}
+	return
+}
+
+// Index returns index of x, or -1 if not exists.
+func (a SliceFunc[T]) Index(x T) int {
+	if i := a.Rank(x); i < len(a.Values) && a.Cmp(a.Values[i], x) == 0 {
+		return i
+	}
+	return -1
+}
+
+// Rank returns number of values less than x, also the index x would be inserted at.
+func (a SliceFunc[T]) Rank(x T) int {
+	return sort.Search(len(a.Values), func(i int) bool { return a.Cmp(a.Values[i], x) >= 0 })
+}
+
+// ChainFunc is always strictly ordered by its indices, given as [0 .. N-1].
+// Each row must be sorted in ascending order according to Cmp.
+type ChainFunc[T any] struct {
+	Cmp  func(a, b T) int
+	Rows [][]T
+}
+
+// Upsert inserts row{x} at i if ok, and returns 0 and true.
+// Otherwise, row at i attempts insert of distinct x, and returns x index and true if inserted.
+func (a *ChainFunc[T]) Upsert(x T, i int, ok bool) (int, bool) {
+	if ok {
+		a.Rows = append(a.Rows, nil)
+		copy(a.Rows[i+1:], a.Rows[i:])
+		a.Rows[i] = []T{x}
+		return 0, true
+	}
+	row := a.Row(i)
+	j, ok := row.Insert(x)
+	a.Rows[i] = row.Values
+	return j, ok
+}
+
+// Row returns the row at i sharing storage and Cmp with a.
+func (a ChainFunc[T]) Row(i int) SliceFunc[T] {
+	return SliceFunc[T]{Cmp: a.Cmp, Values: a.Rows[i]}
+}
@@ -0,0 +1,60 @@
+package set
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type point struct{ x, y int }
+
+func cmpPoint(a, b point) int {
+	if a.x != b.x {
+		return a.x - b.x
+	}
+	return a.y - b.y
+}
+
+func TestSliceFunc(t *testing.T) {
+	a := SliceFunc[point]{Cmp: cmpPoint}
+	for _, p := range []point{{2, 1}, {1, 2}, {1, 1}, {2, 1}, {0, 5}} {
+		a.Insert(p)
+	}
+	if have, want := fmt.Sprint(a.Values), "[{0 5} {1 1} {1 2} {2 1}]"; have != want {
+		t.Fatalf("have %v, want %v", have, want)
+	}
+	if !a.Has(point{1, 2}) || a.Has(point{3, 3}) {
+		t.Fatal("Has returned wrong result")
+	}
+	if i, ok := a.Remove(point{1, 1}); !ok || i != 1 {
+		t.Fatalf("Remove: have %v, %v, want 1, true", i, ok)
+	}
+	if i := a.Index(point{2, 1}); i != 2 {
+		t.Fatalf("Index: have %v, want 2", i)
+	}
+}
+
+func TestSliceFuncFold(t *testing.T) {
+	a := SliceFunc[string]{Cmp: func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}}
+	for _, s := range []string{"b", "A", "a", "B", "c"} {
+		a.Insert(s)
+	}
+	if have, want := fmt.Sprint(a.Values), "[A b c]"; have != want {
+		t.Fatalf("have %v, want %v", have, want)
+	}
+}
+
+func TestChainFunc(t *testing.T) {
+	cmp := func(a, b []byte) int { return strings.Compare(string(a), string(b)) }
+	ks := SliceFunc[[]byte]{Cmp: cmp}
+	vs := ChainFunc[[]byte]{Cmp: cmp}
+	for _, kv := range [][2]string{{"b", "2"}, {"a", "1"}, {"b", "1"}, {"a", "1"}} {
+		i, ok := ks.Insert([]byte(kv[0]))
+		vs.Upsert([]byte(kv[1]), i, ok)
+	}
+	if have, want := fmt.Sprintf("%s %s", ks.Values, vs.Rows), "[a b] [[1] [1 2]]"; have != want {
+		t.Fatalf("have %v, want %v", have, want)
+	}
+}
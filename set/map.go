@@ -0,0 +1,142 @@
+package set
+
+import "golang.org/x/exp/constraints"
+
+// Map associates distinct keys, kept in ascending order, with a value each; zero value is valid.
+type Map[K constraints.Ordered, V any] struct {
+	ks Slice[K]
+	vs Simple[V]
+}
+
+// Put sets value of k to v; returns k index and true if k inserted.
+func (m *Map[K, V]) Put(k K, v V) (int, bool) {
+	i, ok := m.ks.Insert(k)
+	m.vs.Upsert(v, i, ok)
+	return i, ok
+}
+
+// Get returns value of k and true if exists.
+func (m Map[K, V]) Get(k K) (v V, ok bool) {
+	if i := m.ks.Index(k); i != -1 {
+		return m.vs[i], true
+	}
+	return v, false
+}
+
+// Delete k and its value; returns true if deleted.
+func (m *Map[K, V]) Delete(k K) bool {
+	i, ok := m.ks.Remove(k)
+	if ok {
+		copy(m.vs[i:], m.vs[i+1:])
+		m.vs[len(m.vs)-1] = *new(V)
+		m.vs = m.vs[:len(m.vs)-1]
+	}
+	return ok
+}
+
+// Len returns number of keys.
+func (m Map[K, V]) Len() int { return len(m.ks) }
+
+// At returns key and value at index i in key order.
+func (m Map[K, V]) At(i int) (K, V) { return m.ks[i], m.vs[i] }
+
+// Keys returns keys in ascending order; result shares storage with m.
+func (m Map[K, V]) Keys() Slice[K] { return m.ks[:len(m.ks):len(m.ks)] }
+
+// Do executes fn for each key and value in key order until fn returns false.
+func (m Map[K, V]) Do(fn func(k K, v V) bool) {
+	for i, k := range m.ks {
+		if !fn(k, m.vs[i]) {
+			return
+		}
+	}
+}
+
+// Range executes fn for each key in [lo, hi) and its value in key order until fn returns false.
+func (m Map[K, V]) Range(lo, hi K, fn func(k K, v V) bool) {
+	for i, j := m.ks.Rank(lo), m.ks.Rank(hi); i < j; i++ {
+		if !fn(m.ks[i], m.vs[i]) {
+			return
+		}
+	}
+}
+
+// MultiMap associates distinct keys, kept in ascending order, with a set of distinct values each,
+// such as a postings list; zero value is valid.
+type MultiMap[K, V constraints.Ordered] struct {
+	ks Slice[K]
+	vs Chain[V]
+}
+
+// Put inserts v into values of k; returns true if v inserted.
+func (m *MultiMap[K, V]) Put(k K, v V) bool {
+	i, ok := m.ks.Insert(k)
+	_, ok = m.vs.Upsert(v, i, ok)
+	return ok
+}
+
+// Get returns values of k, or nil if not exists; result shares storage with m.
+func (m MultiMap[K, V]) Get(k K) Slice[V] {
+	if i := m.ks.Index(k); i != -1 {
+		return m.vs[i][:len(m.vs[i]):len(m.vs[i])]
+	}
+	return nil
+}
+
+// Remove v from values of k, deleting k if no values remain; returns true if removed.
+func (m *MultiMap[K, V]) Remove(k K, v V) bool {
+	i := m.ks.Index(k)
+	if i == -1 {
+		return false
+	}
+	if _, ok := m.vs[i].Remove(v); !ok {
+		return false
+	}
+	if len(m.vs[i]) == 0 {
+		m.delete(i)
+	}
+	return true
+}
+
+// Delete k and all its values; returns true if deleted.
+func (m *MultiMap[K, V]) Delete(k K) bool {
+	i := m.ks.Index(k)
+	if i != -1 {
+		m.delete(i)
+	}
+	return i != -1
+}
+
+func (m *MultiMap[K, V]) delete(i int) {
+	m.ks = append(m.ks[:i], m.ks[i+1:]...)
+	copy(m.vs[i:], m.vs[i+1:])
+	m.vs[len(m.vs)-1] = nil
+	m.vs = m.vs[:len(m.vs)-1]
+}
+
+// Len returns number of keys.
+func (m MultiMap[K, V]) Len() int { return len(m.ks) }
+
+// At returns key and values at index i in key order; values share storage with m.
+func (m MultiMap[K, V]) At(i int) (K, Slice[V]) { return m.ks[i], m.vs[i][:len(m.vs[i]):len(m.vs[i])] }
+
+// Keys returns keys in ascending order; result shares storage with m.
+func (m MultiMap[K, V]) Keys() Slice[K] { return m.ks[:len(m.ks):len(m.ks)] }
+
+// Do executes fn for each key and its values in key order until fn returns false.
+func (m MultiMap[K, V]) Do(fn func(k K, vs Slice[V]) bool) {
+	for i := range m.ks {
+		if !fn(m.At(i)) {
+			return
+		}
+	}
+}
+
+// Range executes fn for each key in [lo, hi) and its values in key order until fn returns false.
+func (m MultiMap[K, V]) Range(lo, hi K, fn func(k K, vs Slice[V]) bool) {
+	for i, j := m.ks.Rank(lo), m.ks.Rank(hi); i < j; i++ {
+		if !fn(m.At(i)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package set
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	var m Map[string, int]
+	ref := make(map[string]int)
+	for n := 0; n < 1000; n++ {
+		k := fmt.Sprint(rand.Intn(50))
+		switch rand.Intn(3) {
+		case 0, 1:
+			_, ok := m.Put(k, n)
+			_, exists := ref[k]
+			if ok == exists {
+				t.Fatalf("Put(%q) reported inserted %v, but key exists %v", k, ok, exists)
+			}
+			ref[k] = n
+		case 2:
+			_, exists := ref[k]
+			if ok := m.Delete(k); ok != exists {
+				t.Fatalf("Delete(%q) reported %v, but key exists %v", k, ok, exists)
+			}
+			delete(ref, k)
+		}
+	}
+
+	if have, want := m.Len(), len(ref); have != want {
+		t.Fatalf("Len: have %v, want %v", have, want)
+	}
+	for k, want := range ref {
+		if have, ok := m.Get(k); !ok || have != want {
+			t.Fatalf("Get(%q): have %v, %v, want %v, true", k, have, ok, want)
+		}
+	}
+	if _, ok := m.Get("none"); ok {
+		t.Fatal("Get of missing key reported ok")
+	}
+
+	var keys []string
+	m.Do(func(k string, v int) bool {
+		if ref[k] != v {
+			t.Fatalf("Do(%q): have %v, want %v", k, v, ref[k])
+		}
+		keys = append(keys, k)
+		return true
+	})
+	if !sort.StringsAreSorted(keys) || len(keys) != len(ref) {
+		t.Fatalf("Do did not visit keys in order: %v", keys)
+	}
+}
+
+func TestMapRange(t *testing.T) {
+	var m Map[int, string]
+	for i := 0; i < 10; i++ {
+		m.Put(i*10, fmt.Sprint(i))
+	}
+	var have []string
+	m.Range(15, 45, func(k int, v string) bool {
+		have = append(have, v)
+		return true
+	})
+	if want := "[2 3 4]"; fmt.Sprint(have) != want {
+		t.Fatalf("Range: have %v, want %v", have, want)
+	}
+
+	have = have[:0]
+	m.Range(0, 100, func(k int, v string) bool {
+		have = append(have, v)
+		return k < 20
+	})
+	if want := "[0 1 2]"; fmt.Sprint(have) != want {
+		t.Fatalf("Range stop: have %v, want %v", have, want)
+	}
+}
+
+func TestMultiMap(t *testing.T) {
+	var m MultiMap[string, int]
+	for _, kv := range []struct {
+		k string
+		v int
+	}{{"b", 2}, {"a", 3}, {"b", 1}, {"a", 3}, {"c", 1}} {
+		m.Put(kv.k, kv.v)
+	}
+	if have, want := fmt.Sprint(m.Get("a"), m.Get("b"), m.Get("c"), m.Get("d")), "[3] [1 2] [1] []"; have != want {
+		t.Fatalf("Get: have %v, want %v", have, want)
+	}
+
+	if !m.Remove("a", 3) || m.Remove("a", 3) {
+		t.Fatal("Remove reported wrong result")
+	}
+	if have, want := fmt.Sprint(m.Keys()), "[b c]"; have != want {
+		t.Fatalf("Remove of last value did not delete key: have %v, want %v", have, want)
+	}
+	if !m.Delete("b") || m.Delete("b") {
+		t.Fatal("Delete reported wrong result")
+	}
+
+	var have []string
+	m.Do(func(k string, vs Slice[int]) bool {
+		have = append(have, fmt.Sprint(k, vs))
+		return true
+	})
+	if want := "[c[1]]"; fmt.Sprint(have) != want {
+		t.Fatalf("Do: have %v, want %v", have, want)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
 )
 
 // Slice must be sorted in ascending order.
@@ -13,20 +14,236 @@ type Slice[T constraints.Ordered] []T
 // Insert x in place if not exists; returns x index and true if inserted.
 // The slice must be sorted in ascending order.
 func (a *Slice[T]) Insert(x T) (i int, ok bool) {
-	i = sort.Search(len(*a), func(i int) bool { return (*a)[i] >= x })
+	i = a.Rank(x)
 	if ok = i == len(*a) || (*a)[i] != x; ok {
 		*a = upsert(*a, x, i, ok)
 	}
 	return
 }
 
+// InsertMany inserts in place each distinct x of xs not exists; returns number inserted.
+// The batch is sorted separately and merged with a in a single pass.
+func (a *Slice[T]) InsertMany(xs ...T) int {
+	n := len(*a)
+	a.Union(FromUnsorted(xs))
+	return len(*a) - n
+}
+
+// FromUnsorted returns a new slice of distinct xs sorted in ascending order.
+func FromUnsorted[T constraints.Ordered](xs []T) Slice[T] {
+	a := append([]T(nil), xs...)
+	Filter(&a)
+	return a
+}
+
 func (a Slice[T]) Has(x T) bool {
-	i := sort.Search(len(a), func(i int) bool { return a[i] >= x })
+	i := a.Rank(x)
 	return !(i == len(a) || a[i] != x)
 }
 
+// Remove x in place if exists; returns x index and true if removed.
+func (a *Slice[T]) Remove(x T) (i int, ok bool) {
+	i = a.Rank(x)
+	if ok = i < len(*a) && (*a)[i] == x; ok {
+		*a = append((*a)[:i], (*a)[i+1:]...)
+	}
+	return
+}
+
+// Index returns index of x, or -1 if not exists.
+func (a Slice[T]) Index(x T) int {
+	if i := a.Rank(x); i < len(a) && a[i] == x {
+		return i
+	}
+	return -1
+}
+
+// Range returns subslice of values in [lo, hi); result shares storage with a.
+func (a Slice[T]) Range(lo, hi T) Slice[T] {
+	i, j := a.Rank(lo), a.Rank(hi)
+	if j < i {
+		j = i
+	}
+	return a[i:j:j]
+}
+
+// Floor returns greatest value less than or equal to x; ok is false if none.
+func (a Slice[T]) Floor(x T) (y T, ok bool) {
+	i := a.Rank(x)
+	if i < len(a) && a[i] == x {
+		return x, true
+	}
+	if i == 0 {
+		return y, false
+	}
+	return a[i-1], true
+}
+
+// Ceiling returns least value greater than or equal to x; ok is false if none.
+func (a Slice[T]) Ceiling(x T) (y T, ok bool) {
+	if i := a.Rank(x); i < len(a) {
+		return a[i], true
+	}
+	return y, false
+}
+
+// Rank returns number of values less than x, also the index x would be inserted at.
+func (a Slice[T]) Rank(x T) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] >= x })
+}
+
+// Select returns value of rank i, the i-th least value. Panics if out of range.
+func (a Slice[T]) Select(i int) T { return a[i] }
+
+// Union inserts in place each x in b not in a; b must be sorted in ascending order.
+func (a *Slice[T]) Union(b Slice[T]) {
+	n := len(*a)
+	for i, j := 0, 0; j < len(b); {
+		if i < len(*a) && (*a)[i] < b[j] {
+			i++
+		} else if i < len(*a) && (*a)[i] == b[j] {
+			i, j = i+1, j+1
+		} else {
+			n, j = n+1, j+1
+		}
+	}
+	if n == len(*a) {
+		return
+	}
+
+	// merge from the back so unread elements of a are never overwritten.
+	c := grow(*a, n)
+	for i, j, k := len(*a)-1, len(b)-1, n-1; j >= 0; k-- {
+		if i >= 0 && c[i] > b[j] {
+			c[k], i = c[i], i-1
+		} else if i >= 0 && c[i] == b[j] {
+			c[k], i, j = c[i], i-1, j-1
+		} else {
+			c[k], j = b[j], j-1
+		}
+	}
+	*a = c
+}
+
+// Intersect removes in place each x in a not in b; b must be sorted in ascending order.
+func (a *Slice[T]) Intersect(b Slice[T]) {
+	c := (*a)[:0]
+	for i, j := 0, 0; i < len(*a) && j < len(b); {
+		if (*a)[i] < b[j] {
+			i++
+		} else if b[j] < (*a)[i] {
+			j++
+		} else {
+			c = append(c, (*a)[i])
+			i, j = i+1, j+1
+		}
+	}
+	*a = c
+}
+
+// Difference removes in place each x in a also in b; b must be sorted in ascending order.
+func (a *Slice[T]) Difference(b Slice[T]) {
+	c := (*a)[:0]
+	i := 0
+	for j := 0; i < len(*a) && j < len(b); {
+		if (*a)[i] < b[j] {
+			c = append(c, (*a)[i])
+			i++
+		} else if b[j] < (*a)[i] {
+			j++
+		} else {
+			i, j = i+1, j+1
+		}
+	}
+	*a = append(c, (*a)[i:]...)
+}
+
+// SymmetricDifference keeps in place each x in exactly one of a or b;
+// b must be sorted in ascending order.
+func (a *Slice[T]) SymmetricDifference(b Slice[T]) {
+	d := Difference(b, *a)
+	a.Difference(b)
+	a.Union(d)
+}
+
+// Union returns a new slice of each x in a or b.
+func Union[T constraints.Ordered](a, b Slice[T]) Slice[T] {
+	c := make(Slice[T], 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] < b[j] {
+			c = append(c, a[i])
+			i++
+		} else if b[j] < a[i] {
+			c = append(c, b[j])
+			j++
+		} else {
+			c = append(c, a[i])
+			i, j = i+1, j+1
+		}
+	}
+	c = append(c, a[i:]...)
+	return append(c, b[j:]...)
+}
+
+// Intersect returns a new slice of each x in both a and b.
+func Intersect[T constraints.Ordered](a, b Slice[T]) Slice[T] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	c := make(Slice[T], 0, n)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if a[i] < b[j] {
+			i++
+		} else if b[j] < a[i] {
+			j++
+		} else {
+			c = append(c, a[i])
+			i, j = i+1, j+1
+		}
+	}
+	return c
+}
+
+// Difference returns a new slice of each x in a not in b.
+func Difference[T constraints.Ordered](a, b Slice[T]) Slice[T] {
+	c := make(Slice[T], 0, len(a))
+	i := 0
+	for j := 0; i < len(a) && j < len(b); {
+		if a[i] < b[j] {
+			c = append(c, a[i])
+			i++
+		} else if b[j] < a[i] {
+			j++
+		} else {
+			i, j = i+1, j+1
+		}
+	}
+	return append(c, a[i:]...)
+}
+
+// SymmetricDifference returns a new slice of each x in exactly one of a or b.
+func SymmetricDifference[T constraints.Ordered](a, b Slice[T]) Slice[T] {
+	c := make(Slice[T], 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] < b[j] {
+			c = append(c, a[i])
+			i++
+		} else if b[j] < a[i] {
+			c = append(c, b[j])
+			j++
+		} else {
+			i, j = i+1, j+1
+		}
+	}
+	c = append(c, a[i:]...)
+	return append(c, b[j:]...)
+}
+
 // Simple is always strictly ordered by its indices, given as [0 .. N-1].
-type Simple[T constraints.Ordered] []T
+type Simple[T any] []T
 
 // Upsert inserts x at i if ok; otherwise, updates i to x.
 func (a *Simple[T]) Upsert(x T, i int, ok bool) { *a = upsert(*a, x, i, ok) }
@@ -47,7 +264,57 @@ func (a *Chain[T]) Upsert(x T, i int, ok bool) (int, bool) {
 	}
 }
 
-func upsert[T constraints.Ordered](a []T, x T, i int, ok bool) []T {
+// Union returns a new slice of each x in any of the slices at the given indices.
+func (a Chain[T]) Union(is ...int) Slice[T] {
+	var c Slice[T]
+	for _, i := range is {
+		c.Union(a[i])
+	}
+	return c
+}
+
+// Intersect returns a new slice of each x in all of the slices at the given indices.
+// Slices are visited from shortest to longest so the result shrinks early.
+func (a Chain[T]) Intersect(is ...int) Slice[T] {
+	if len(is) == 0 {
+		return nil
+	}
+	is = append([]int(nil), is...)
+	sort.Slice(is, func(i, j int) bool { return len(a[is[i]]) < len(a[is[j]]) })
+	c := append(Slice[T](nil), a[is[0]]...)
+	for _, i := range is[1:] {
+		if len(c) == 0 {
+			break
+		}
+		c.Intersect(a[i])
+	}
+	return c
+}
+
+// Difference returns a new slice of each x in the slice at the first index
+// not in any of the slices at the remaining indices.
+func (a Chain[T]) Difference(is ...int) Slice[T] {
+	if len(is) == 0 {
+		return nil
+	}
+	c := append(Slice[T](nil), a[is[0]]...)
+	for _, i := range is[1:] {
+		c.Difference(a[i])
+	}
+	return c
+}
+
+// SymmetricDifference returns a new slice of each x in an odd number
+// of the slices at the given indices.
+func (a Chain[T]) SymmetricDifference(is ...int) Slice[T] {
+	var c Slice[T]
+	for _, i := range is {
+		c = SymmetricDifference(c, a[i])
+	}
+	return c
+}
+
+func upsert[T any](a []T, x T, i int, ok bool) []T {
 	if ok {
 		a = append(a, *new(T))
 		copy(a[i+1:], a[i:])
@@ -56,11 +323,16 @@ func upsert[T constraints.Ordered](a []T, x T, i int, ok bool) []T {
 	return a
 }
 
-// Filter without allocating.
-func Filter[T constraints.Ordered](a *[]T) {
-	b := Slice[T]((*a)[:0])
-	for _, x := range (*a) {
-		b.Insert(x)
+// grow returns a with length n, reallocating only if capacity is exceeded.
+func grow[T any](a []T, n int) []T {
+	if n <= cap(a) {
+		return a[:n]
 	}
-	*a = b
-}
\ No newline at end of file
+	return append(a, make([]T, n-len(a))...)
+}
+
+// Filter without allocating; sorts a in ascending order and removes duplicates.
+func Filter[T constraints.Ordered](a *[]T) {
+	slices.Sort(*a)
+	*a = slices.Compact(*a)
+}
@@ -80,6 +80,267 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+// naive returns sorted results of set algebra on a and b using maps.
+func naive(a, b []int) (union, intersect, difference, symmetric []int) {
+	ma, mb := make(map[int]bool), make(map[int]bool)
+	for _, x := range a {
+		ma[x] = true
+	}
+	for _, x := range b {
+		mb[x] = true
+	}
+	for x := range ma {
+		union = append(union, x)
+		if mb[x] {
+			intersect = append(intersect, x)
+		} else {
+			difference = append(difference, x)
+			symmetric = append(symmetric, x)
+		}
+	}
+	for x := range mb {
+		if !ma[x] {
+			union = append(union, x)
+			symmetric = append(symmetric, x)
+		}
+	}
+	sort.Ints(union)
+	sort.Ints(intersect)
+	sort.Ints(difference)
+	sort.Ints(symmetric)
+	return
+}
+
+func randSlice(n, max int) Slice[int] {
+	var a Slice[int]
+	for i := 0; i < n; i++ {
+		a.Insert(rand.Intn(max))
+	}
+	return a
+}
+
+func TestAlgebra(t *testing.T) {
+	eq := func(a Slice[int], b []int) bool {
+		return fmt.Sprint([]int(a)) == fmt.Sprint(append([]int{}, b...))
+	}
+	for n := 0; n < 200; n++ {
+		a, b := randSlice(rand.Intn(20), 30), randSlice(rand.Intn(20), 30)
+		union, intersect, difference, symmetric := naive(a, b)
+
+		if have := Union(a, b); !eq(have, union) {
+			t.Fatalf("Union(%v, %v)\nhave %v\nwant %v", a, b, have, union)
+		}
+		if have := Intersect(a, b); !eq(have, intersect) {
+			t.Fatalf("Intersect(%v, %v)\nhave %v\nwant %v", a, b, have, intersect)
+		}
+		if have := Difference(a, b); !eq(have, difference) {
+			t.Fatalf("Difference(%v, %v)\nhave %v\nwant %v", a, b, have, difference)
+		}
+		if have := SymmetricDifference(a, b); !eq(have, symmetric) {
+			t.Fatalf("SymmetricDifference(%v, %v)\nhave %v\nwant %v", a, b, have, symmetric)
+		}
+
+		ops := []struct {
+			name string
+			fn   func(*Slice[int], Slice[int])
+			want []int
+		}{
+			{"Union", (*Slice[int]).Union, union},
+			{"Intersect", (*Slice[int]).Intersect, intersect},
+			{"Difference", (*Slice[int]).Difference, difference},
+			{"SymmetricDifference", (*Slice[int]).SymmetricDifference, symmetric},
+		}
+		for _, op := range ops {
+			// extra capacity exercises in place writes over the same backing array.
+			c := append(make(Slice[int], 0, len(a)+len(b)), a...)
+			op.fn(&c, b)
+			if !eq(c, op.want) {
+				t.Fatalf("in place %s(%v, %v)\nhave %v\nwant %v", op.name, a, b, c, op.want)
+			}
+		}
+	}
+}
+
+func TestChainAlgebra(t *testing.T) {
+	a := Chain[int]{
+		{1, 2, 3, 4},
+		{2, 3, 5},
+		{3, 4, 5, 6},
+	}
+	tests := []struct {
+		name string
+		have Slice[int]
+		want string
+	}{
+		{"Union", a.Union(0, 1, 2), "[1 2 3 4 5 6]"},
+		{"Intersect", a.Intersect(0, 1, 2), "[3]"},
+		{"Intersect", a.Intersect(0, 2), "[3 4]"},
+		{"Difference", a.Difference(0, 1, 2), "[1]"},
+		{"SymmetricDifference", a.SymmetricDifference(0, 1, 2), "[1 3 6]"},
+		{"Intersect", a.Intersect(), "[]"},
+	}
+	for _, tt := range tests {
+		if have := fmt.Sprint(tt.have); have != tt.want {
+			t.Errorf("%s: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+	if want, have := "[[1 2 3 4] [2 3 5] [3 4 5 6]]", fmt.Sprint(a); have != want {
+		t.Fatalf("Chain modified by set algebra: have %v, want %v", have, want)
+	}
+}
+
+func BenchmarkUnion_Insert(b *testing.B) {
+	p, q := randSlice(1000, 4000), randSlice(1000, 4000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		c := append(Slice[int](nil), p...)
+		for _, x := range q {
+			c.Insert(x)
+		}
+	}
+}
+
+func BenchmarkUnion_Merge(b *testing.B) {
+	p, q := randSlice(1000, 4000), randSlice(1000, 4000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = Union(p, q)
+	}
+}
+
+func TestFromUnsorted(t *testing.T) {
+	a := FromUnsorted(dups)
+	if !sort.StringsAreSorted(a) {
+		t.Fatal("sort.StringsAreSorted returned false")
+	}
+	if have, want := len(a), N/2; have != want {
+		t.Fatalf("Unexpected len; have %v, want %v.", have, want)
+	}
+	for i, s := range dups {
+		if !a.Has(s) {
+			t.Fatalf("FromUnsorted missing dups[%v]", i)
+		}
+	}
+}
+
+func TestInsertMany(t *testing.T) {
+	for n := 0; n < 100; n++ {
+		a := randSlice(rand.Intn(20), 30)
+		var xs []int
+		for i := rand.Intn(20); i > 0; i-- {
+			xs = append(xs, rand.Intn(30))
+		}
+
+		want := append(Slice[int](nil), a...)
+		var inserted int
+		for _, x := range xs {
+			if _, ok := want.Insert(x); ok {
+				inserted++
+			}
+		}
+
+		p := fmt.Sprint(xs)
+		have := append(Slice[int](nil), a...)
+		if k := have.InsertMany(xs...); k != inserted {
+			t.Fatalf("InsertMany(%v) into %v reported %v inserted, want %v", xs, a, k, inserted)
+		}
+		if fmt.Sprint(have) != fmt.Sprint(want) {
+			t.Fatalf("InsertMany(%v) into %v\nhave %v\nwant %v", xs, a, have, want)
+		}
+		if fmt.Sprint(xs) != p {
+			t.Fatalf("InsertMany modified its arguments: have %v, want %v", xs, p)
+		}
+	}
+}
+
+func TestRemove(t *testing.T) {
+	a := FromUnsorted(uniq)
+	for n, j := range rand.Perm(N) {
+		s := uniq[j]
+		i, ok := a.Remove(s)
+		if !ok {
+			t.Fatalf("Remove(uniq[%v]) failed", j)
+		}
+		if a.Has(s) || a.Index(s) != -1 {
+			t.Fatalf("uniq[%v] still exists after Remove", j)
+		}
+		if a.Rank(s) != i {
+			t.Fatalf("Rank after Remove; have %v, want %v", a.Rank(s), i)
+		}
+		if _, ok := a.Remove(s); ok {
+			t.Fatalf("repeat Remove(uniq[%v]) reported ok", j)
+		}
+		if !sort.StringsAreSorted(a) {
+			t.Fatal("sort.StringsAreSorted returned false")
+		}
+		if have, want := len(a), N-n-1; have != want {
+			t.Fatalf("Unexpected len after Remove; have %v, want %v.", have, want)
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	a := Slice[int]{10, 20, 30, 40}
+	tests := []struct {
+		x               int
+		index, rank     int
+		floor, ceiling  int
+		hasFloor, hasCl bool
+	}{
+		{5, -1, 0, 0, 10, false, true},
+		{10, 0, 0, 10, 10, true, true},
+		{15, -1, 1, 10, 20, true, true},
+		{40, 3, 3, 40, 40, true, true},
+		{45, -1, 4, 40, 0, true, false},
+	}
+	for _, tt := range tests {
+		if have := a.Index(tt.x); have != tt.index {
+			t.Errorf("Index(%v): have %v, want %v", tt.x, have, tt.index)
+		}
+		if have := a.Rank(tt.x); have != tt.rank {
+			t.Errorf("Rank(%v): have %v, want %v", tt.x, have, tt.rank)
+		}
+		if have, ok := a.Floor(tt.x); have != tt.floor || ok != tt.hasFloor {
+			t.Errorf("Floor(%v): have %v, %v, want %v, %v", tt.x, have, ok, tt.floor, tt.hasFloor)
+		}
+		if have, ok := a.Ceiling(tt.x); have != tt.ceiling || ok != tt.hasCl {
+			t.Errorf("Ceiling(%v): have %v, %v, want %v, %v", tt.x, have, ok, tt.ceiling, tt.hasCl)
+		}
+	}
+	for i, x := range a {
+		if have := a.Select(i); have != x {
+			t.Errorf("Select(%v): have %v, want %v", i, have, x)
+		}
+		if have := a.Rank(a.Select(i)); have != i {
+			t.Errorf("Rank(Select(%v)): have %v, want %v", i, have, i)
+		}
+	}
+
+	ranges := []struct {
+		lo, hi int
+		want   string
+	}{
+		{0, 100, "[10 20 30 40]"},
+		{10, 40, "[10 20 30]"},
+		{11, 41, "[20 30 40]"},
+		{20, 20, "[]"},
+		{30, 10, "[]"},
+		{50, 60, "[]"},
+	}
+	for _, tt := range ranges {
+		if have := fmt.Sprint(a.Range(tt.lo, tt.hi)); have != tt.want {
+			t.Errorf("Range(%v, %v): have %v, want %v", tt.lo, tt.hi, have, tt.want)
+		}
+	}
+
+	// appending to a range must not clobber a.
+	b := a.Range(10, 30)
+	b = append(b, 99)
+	if have, want := fmt.Sprint(a), "[10 20 30 40]"; have != want {
+		t.Errorf("append to Range modified slice: have %v, want %v", have, want)
+	}
+}
+
 func BenchmarkFilter(b *testing.B) {
 	b.ReportAllocs()
 	p := []string{
@@ -113,6 +374,38 @@ func BenchmarkDups_Generic_Slice(b *testing.B) {
 	}
 }
 
+func BenchmarkUniq_Generic_FromUnsorted(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		_ = FromUnsorted(uniq)
+	}
+}
+
+func BenchmarkDups_Generic_FromUnsorted(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		_ = FromUnsorted(dups)
+	}
+}
+
+func BenchmarkInsert_Batch(b *testing.B) {
+	p, q := randSlice(1000, 4000), rand.Perm(4000)[:1000]
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		c := append(Slice[int](nil), p...)
+		for _, x := range q {
+			c.Insert(x)
+		}
+	}
+}
+
+func BenchmarkInsertMany_Batch(b *testing.B) {
+	p, q := randSlice(1000, 4000), rand.Perm(4000)[:1000]
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		c := append(Slice[int](nil), p...)
+		c.InsertMany(q...)
+	}
+}
+
 func BenchmarkUniq_String_Map(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		m := make(map[string]struct{})
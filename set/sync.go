@@ -0,0 +1,82 @@
+package set
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/constraints"
+)
+
+// SyncSlice is a sorted set safe for concurrent use; zero value is valid.
+//
+// Reads operate on an immutable snapshot and never block. Writes are serialized,
+// modifying a copy of the current snapshot that is then published for later reads.
+type SyncSlice[T constraints.Ordered] struct {
+	mu sync.Mutex
+	p  atomic.Pointer[Slice[T]]
+}
+
+// Load returns current snapshot; the result must not be modified.
+func (a *SyncSlice[T]) Load() Slice[T] {
+	if p := a.p.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+func (a *SyncSlice[T]) Has(x T) bool { return a.Load().Has(x) }
+
+// Len returns number of values in current snapshot.
+func (a *SyncSlice[T]) Len() int { return len(a.Load()) }
+
+// Range returns values of current snapshot in [lo, hi); the result must not be modified.
+func (a *SyncSlice[T]) Range(lo, hi T) Slice[T] { return a.Load().Range(lo, hi) }
+
+// Insert x if not exists; returns true if inserted.
+func (a *SyncSlice[T]) Insert(x T) (ok bool) {
+	a.Update(func(b Slice[T]) Slice[T] {
+		if ok = !b.Has(x); ok {
+			c := make(Slice[T], len(b), len(b)+1)
+			copy(c, b)
+			c.Insert(x)
+			return c
+		}
+		return b
+	})
+	return
+}
+
+// InsertMany inserts each distinct x of xs not exists; returns number inserted.
+func (a *SyncSlice[T]) InsertMany(xs ...T) (n int) {
+	a.Update(func(b Slice[T]) Slice[T] {
+		c := Union(b, FromUnsorted(xs))
+		if n = len(c) - len(b); n == 0 {
+			return b
+		}
+		return c
+	})
+	return
+}
+
+// Remove x if exists; returns true if removed.
+func (a *SyncSlice[T]) Remove(x T) (ok bool) {
+	a.Update(func(b Slice[T]) Slice[T] {
+		i := b.Index(x)
+		if ok = i != -1; ok {
+			c := make(Slice[T], 0, len(b)-1)
+			return append(append(c, b[:i]...), b[i+1:]...)
+		}
+		return b
+	})
+	return
+}
+
+// Update serializes with other writes and publishes result of fn as the next snapshot.
+// Argument to fn is the current snapshot and must not be modified; fn must return
+// a new slice sorted in ascending order or its argument unchanged.
+func (a *SyncSlice[T]) Update(fn func(Slice[T]) Slice[T]) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	c := fn(a.Load())
+	a.p.Store(&c)
+}
@@ -0,0 +1,86 @@
+package set
+
+import (
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSyncSlice(t *testing.T) {
+	var a SyncSlice[int]
+	if a.Len() != 0 || a.Has(0) {
+		t.Fatal("zero value not empty")
+	}
+
+	s := a.Load()
+	if !a.Insert(3) || a.Insert(3) || !a.Insert(1) {
+		t.Fatal("Insert reported wrong result")
+	}
+	if n := a.InsertMany(2, 1, 5, 2); n != 2 {
+		t.Fatalf("InsertMany: have %v, want 2", n)
+	}
+	if !a.Remove(5) || a.Remove(5) {
+		t.Fatal("Remove reported wrong result")
+	}
+	if len(s) != 0 {
+		t.Fatalf("earlier snapshot modified: %v", s)
+	}
+
+	s = a.Load()
+	a.Insert(0)
+	if have, want := len(s), 3; have != want {
+		t.Fatalf("snapshot modified by Insert: have len %v, want %v", have, want)
+	}
+	if have := a.Range(1, 3); len(have) != 2 || have[0] != 1 || have[1] != 2 {
+		t.Fatalf("Range: have %v, want [1 2]", have)
+	}
+}
+
+func TestSyncSliceConcurrent(t *testing.T) {
+	const W, R, K = 4, 4, 250
+
+	var a SyncSlice[int]
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	for r := 0; r < R; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if s := a.Load(); !sort.IntsAreSorted(s) {
+					t.Error("snapshot not sorted")
+					return
+				}
+				a.Has(K)
+				a.Range(0, K)
+			}
+		}()
+	}
+
+	var ww sync.WaitGroup
+	for w := 0; w < W; w++ {
+		ww.Add(1)
+		go func(w int) {
+			defer ww.Done()
+			for i := 0; i < K; i++ {
+				a.Insert(i*W + w)
+				if i%2 == 0 {
+					a.Remove(i*W + w)
+				}
+			}
+		}(w)
+	}
+	ww.Wait()
+	close(done)
+	wg.Wait()
+
+	if have, want := a.Len(), W*K/2; have != want {
+		t.Fatalf("Len: have %v, want %v", have, want)
+	}
+}
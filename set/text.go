@@ -0,0 +1,168 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Text format of Slice is elements separated by a space within brackets, such as [1 2 3];
+// integers are written in decimal, floats in their shortest representation and strings
+// quoted as by strconv.Quote. Text format of Chain is each of its slices separated by a
+// space within brackets, such as [[1 2] [] [3]].
+//
+// Methods are not those of encoding.TextMarshaler so that encoding/json continues to
+// encode Slice and Chain as arrays.
+
+var errText = errors.New("set: invalid text")
+
+// AppendText appends text format of a to b.
+func (a Slice[T]) AppendText(b []byte) []byte {
+	b = append(b, '[')
+	for i, x := range a {
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		b = appendText(b, reflect.ValueOf(x))
+	}
+	return append(b, ']')
+}
+
+// ParseText replaces contents of a with text format in text.
+func (a *Slice[T]) ParseText(text []byte) error {
+	c, s, err := parseSlice[T](string(text))
+	if err != nil {
+		return err
+	}
+	if s != "" {
+		return errTrailing
+	}
+	*a = c
+	return nil
+}
+
+// AppendText appends text format of a to b.
+func (a Chain[T]) AppendText(b []byte) []byte {
+	b = append(b, '[')
+	for i, s := range a {
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		b = s.AppendText(b)
+	}
+	return append(b, ']')
+}
+
+// ParseText replaces contents of a with text format in text.
+func (a *Chain[T]) ParseText(text []byte) error {
+	s := string(text)
+	if !strings.HasPrefix(s, "[") {
+		return errText
+	}
+	s = s[1:]
+	var c Chain[T]
+	for !strings.HasPrefix(s, "]") {
+		if len(c) > 0 {
+			if !strings.HasPrefix(s, " ") {
+				return errText
+			}
+			s = s[1:]
+		}
+		p, rest, err := parseSlice[T](s)
+		if err != nil {
+			return err
+		}
+		c, s = append(c, p), rest
+	}
+	if s = s[1:]; s != "" {
+		return errTrailing
+	}
+	*a = c
+	return nil
+}
+
+// parseSlice parses text format of Slice at start of s; returns remaining text.
+func parseSlice[T constraints.Ordered](s string) (Slice[T], string, error) {
+	if !strings.HasPrefix(s, "[") {
+		return nil, "", errText
+	}
+	s = s[1:]
+	var c Slice[T]
+	for !strings.HasPrefix(s, "]") {
+		if len(c) > 0 {
+			if !strings.HasPrefix(s, " ") {
+				return nil, "", errText
+			}
+			s = s[1:]
+		}
+		var x T
+		n, err := parseText(reflect.ValueOf(&x).Elem(), s)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(c) > 0 && !(c[len(c)-1] < x) {
+			return nil, "", errUnsorted
+		}
+		c, s = append(c, x), s[n:]
+	}
+	return c, s[1:], nil
+}
+
+// appendText appends text of an element.
+func appendText(b []byte, v reflect.Value) []byte {
+	switch {
+	case v.Kind() == reflect.String:
+		return strconv.AppendQuote(b, v.String())
+	case v.CanInt():
+		return strconv.AppendInt(b, v.Int(), 10)
+	case v.CanUint():
+		return strconv.AppendUint(b, v.Uint(), 10)
+	case v.CanFloat():
+		return strconv.AppendFloat(b, v.Float(), 'g', -1, v.Type().Bits())
+	}
+	panic("unreachable; ordered types are strings, integers or floats")
+}
+
+// parseText sets v to text of an element at start of s; returns length of text.
+func parseText(v reflect.Value, s string) (int, error) {
+	if v.Kind() == reflect.String {
+		if !strings.HasPrefix(s, `"`) {
+			return 0, errText
+		}
+		q, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return 0, errText
+		}
+		x, _ := strconv.Unquote(q)
+		v.SetString(x)
+		return len(q), nil
+	}
+
+	n := strings.IndexAny(s, " ]")
+	if n == -1 {
+		n = len(s)
+	}
+	var err error
+	switch {
+	case v.CanInt():
+		var x int64
+		x, err = strconv.ParseInt(s[:n], 10, v.Type().Bits())
+		v.SetInt(x)
+	case v.CanUint():
+		var x uint64
+		x, err = strconv.ParseUint(s[:n], 10, v.Type().Bits())
+		v.SetUint(x)
+	case v.CanFloat():
+		var x float64
+		x, err = strconv.ParseFloat(s[:n], v.Type().Bits())
+		v.SetFloat(x)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("set: invalid element %q: %w", s[:n], errors.Unwrap(err))
+	}
+	return n, nil
+}
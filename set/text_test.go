@@ -0,0 +1,111 @@
+package set
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		a    interface{ AppendText([]byte) []byte }
+		b    interface{ ParseText([]byte) error }
+		want string
+	}{
+		{Slice[int]{}, new(Slice[int]), "[]"},
+		{Slice[int]{math.MinInt64, -1, 0, 1, math.MaxInt64}, new(Slice[int]), "[-9223372036854775808 -1 0 1 9223372036854775807]"},
+		{Slice[uint8]{0, 255}, new(Slice[uint8]), "[0 255]"},
+		{Slice[uint64]{0, math.MaxUint64}, new(Slice[uint64]), "[0 18446744073709551615]"},
+		{Slice[float64]{math.Inf(-1), -1.5, 0.1, math.Inf(1)}, new(Slice[float64]), "[-Inf -1.5 0.1 +Inf]"},
+		{Slice[float32]{0.1, 2}, new(Slice[float32]), "[0.1 2]"},
+		{Slice[string]{"", "a b", "c]\"d", "é"}, new(Slice[string]), `["" "a b" "c]\"d" "é"]`},
+		{Chain[string]{{"a", "b"}, {}, {"c"}}, new(Chain[string]), `[["a" "b"] [] ["c"]]`},
+		{Chain[uint32]{}, new(Chain[uint32]), "[]"},
+	}
+	for _, tt := range tests {
+		text := tt.a.AppendText(nil)
+		if string(text) != tt.want {
+			t.Fatalf("AppendText(%v): have %s, want %s", tt.a, text, tt.want)
+		}
+		if err := tt.b.ParseText(text); err != nil {
+			t.Fatalf("ParseText(%s): %v", text, err)
+		}
+		if fmt.Sprint(tt.b) != "&"+fmt.Sprint(tt.a) {
+			t.Fatalf("round trip: have %v, want %v", tt.b, tt.a)
+		}
+	}
+
+	roundTripText(t, FromUnsorted(uniq))
+}
+
+func roundTripText[T constraints.Ordered](t *testing.T, a Slice[T]) {
+	t.Helper()
+	var b Slice[T]
+	if err := b.ParseText(a.AppendText(nil)); err != nil {
+		t.Fatal(err)
+	}
+	if len(a) != len(b) {
+		t.Fatalf("round trip: have %v elements, want %v", len(b), len(a))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("round trip: have %v, want %v", b[i], a[i])
+		}
+	}
+}
+
+func TestTextJSON(t *testing.T) {
+	if _, ok := interface{}(Slice[int]{}).(encoding.TextMarshaler); ok {
+		t.Fatal("Slice implements encoding.TextMarshaler")
+	}
+	b, err := json.Marshal(Slice[int]{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "[1,2]" {
+		t.Fatalf("json.Marshal: have %s, want [1,2]", b)
+	}
+}
+
+func TestTextErrors(t *testing.T) {
+	for _, text := range []string{"", "[", "1 2", "[1 2", "[1  2]", "[1,2]", "[2 1]", "[1 1]", "[1] ", "[x]", "[300]"} {
+		var a Slice[uint8]
+		if err := a.ParseText([]byte(text)); err == nil {
+			t.Errorf("%q: expected error, have %v", text, a)
+		}
+	}
+	for _, text := range []string{`[a]`, `["a]`, `["b" "a"]`, "['a']"} {
+		var a Slice[string]
+		if err := a.ParseText([]byte(text)); err == nil {
+			t.Errorf("%q: expected error, have %q", text, a)
+		}
+	}
+	for _, text := range []string{"[", "[[]", "[[1][2]]", "[[2 1]]", "[[]] ", "[1]"} {
+		var a Chain[int]
+		if err := a.ParseText([]byte(text)); err == nil {
+			t.Errorf("%q: expected error, have %v", text, a)
+		}
+	}
+}
+
+func FuzzSliceParseText(f *testing.F) {
+	for _, a := range []Slice[string]{{}, {"a", "b c"}, {"\x00", "\"]"}} {
+		f.Add(a.AppendText(nil))
+	}
+	f.Fuzz(func(t *testing.T, text []byte) {
+		var a Slice[string]
+		if err := a.ParseText(text); err != nil {
+			return
+		}
+		for i := 1; i < len(a); i++ {
+			if a[i-1] >= a[i] {
+				t.Fatalf("parsed slice not strictly ascending: %q", a)
+			}
+		}
+		roundTripText(t, a)
+	})
+}
@@ -0,0 +1,254 @@
+package trigram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+
+	"dasa.cc/x/set"
+)
+
+// Binary format of Set, all integers as little endian uint32:
+//
+//	header   magic, version, key width, key count, value count, postings count, checksum, reserved
+//	keys     key count keys of key width bytes each in ascending order, zero padded to 4 byte alignment
+//	offsets  key count + 1 offsets into postings; postings of key i are in [offsets[i], offsets[i+1])
+//	postings indices into values in ascending order for each key
+//	voffsets value count + 1 offsets into vdata; value i is vdata[voffsets[i]:voffsets[i+1]]
+//	vdata    bytes of each distinct value in ascending order
+//
+// Checksum is CRC-32 with Castagnoli polynomial of all data following the header.
+// Every section is aligned so that the format may be searched in place, such as
+// when memory mapped, without decoding.
+
+// Version of binary format written by Set.WriteTo.
+const Version = 1
+
+const headerSize = 32
+
+var magic = [4]byte{'t', 'r', 'g', 'm'}
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+var (
+	// ErrFormat is returned when data is not of the binary format.
+	ErrFormat = errors.New("trigram: invalid format")
+
+	// ErrVersion is returned when data is of a binary format version not supported.
+	ErrVersion = errors.New("trigram: unsupported version")
+
+	// ErrChecksum is returned when data does not match its checksum.
+	ErrChecksum = errors.New("trigram: checksum mismatch")
+)
+
+// WriteTo writes binary format of a to w; implements io.WriterTo.
+// Parser functions are not written; gram size N is written as key width.
+func (a Set) WriteTo(w io.Writer) (int64, error) {
+	vals := a.ls.Keys()
+	rank := make(map[string]int, len(vals))
+	for i, v := range vals {
+		rank[v] = i
+	}
+
+	p := a.Parser()
+	p.init()
+	width := p.N
+
+	var body []byte
+	for _, k := range a.ks {
+		if len(k) != width {
+			return 0, fmt.Errorf("trigram: key %q length does not match %v", k, width)
+		}
+		body = append(body, k...)
+	}
+	body = append(body, make([]byte, pad4(len(body)))...)
+
+	u32 := func(x int) { body = binary.LittleEndian.AppendUint32(body, uint32(x)) }
+
+	var npost int
+	u32(0)
+	for _, p := range a.vs {
+		npost += len(p)
+		u32(npost)
+	}
+	for _, p := range a.vs {
+		for _, v := range p {
+			u32(rank[v])
+		}
+	}
+	var n int
+	u32(0)
+	for _, v := range vals {
+		n += len(v)
+		u32(n)
+	}
+	for _, v := range vals {
+		body = append(body, v...)
+	}
+
+	var head [headerSize]byte
+	copy(head[:], magic[:])
+	for i, x := range []int{Version, width, len(a.ks), len(vals), npost} {
+		binary.LittleEndian.PutUint32(head[4+4*i:], uint32(x))
+	}
+	binary.LittleEndian.PutUint32(head[24:], crc32.Checksum(body, castagnoli))
+
+	k, err := w.Write(head[:])
+	if err != nil {
+		return int64(k), err
+	}
+	m, err := w.Write(body)
+	return int64(k + m), err
+}
+
+// ReadFrom replaces contents of a with binary format read from r until EOF;
+// implements io.ReaderFrom. Parser functions are not modified, or defaults are set if nil,
+// and N is set to key width if not positive. Returns ErrFormat if N does not match key width.
+func (a *Set) ReadFrom(r io.Reader) (int64, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(b)), err
+	}
+	v, err := NewView(b)
+	if err != nil {
+		return int64(len(b)), err
+	}
+	if a.N > 0 && a.N != v.width {
+		return int64(len(b)), fmt.Errorf("%w: key width %v does not match N %v", ErrFormat, v.width, a.N)
+	}
+
+	a.N = v.width
+	a.init()
+	a.postings = postings[string]{}
+	a.ks = make(set.Slice[string], v.nkeys)
+	a.vs = make(set.Chain[string], v.nkeys)
+	vals := make([]string, v.nvals)
+	for i := range vals {
+		vals[i] = string(v.value(i))
+	}
+	counts := make([]int, v.nvals)
+	for i := range a.ks {
+		a.ks[i] = string(v.key(i))
+		lo, hi := v.postings(i)
+		a.vs[i] = make(set.Slice[string], hi-lo)
+		for j := range a.vs[i] {
+			k := v.posting(lo + j)
+			a.vs[i][j] = vals[k]
+			counts[k]++
+		}
+		a.nt += hi - lo
+	}
+	// values are in ascending order, appending to ls.
+	for k, n := range counts {
+		if n > 0 {
+			a.ls.Put(vals[k], n)
+		}
+	}
+	return int64(len(b)), nil
+}
+
+// View is a read only Set searched in place from its binary format.
+type View struct {
+	Parser
+
+	width, nkeys, nvals int
+	keys, offs, posts   []byte
+	voffs, vdata        []byte
+}
+
+// NewView returns a View of binary format in b, such as a memory mapped file written by
+// Set.WriteTo, with Parser N set to key width. Returns error if b is not of the format, its
+// version is not supported, or it does not match its checksum. Contents of b must not be
+// modified while in use.
+func NewView(b []byte) (*View, error) {
+	if len(b) < headerSize || !bytes.Equal(b[:4], magic[:]) {
+		return nil, ErrFormat
+	}
+	u32 := func(i int) int { return int(binary.LittleEndian.Uint32(b[4+4*i:])) }
+	if ver := u32(0); ver != Version {
+		return nil, fmt.Errorf("%w: have %v, want %v", ErrVersion, ver, Version)
+	}
+	v := &View{width: u32(1), nkeys: u32(2), nvals: u32(3)}
+	v.N = v.width
+	npost, sum := u32(4), uint32(u32(5))
+
+	body := b[headerSize:]
+	if crc32.Checksum(body, castagnoli) != sum {
+		return nil, ErrChecksum
+	}
+
+	nk := v.width * v.nkeys
+	sizes := []int{nk + pad4(nk), 4 * (v.nkeys + 1), 4 * npost, 4 * (v.nvals + 1)}
+	var sections [4][]byte
+	for i, n := range sizes {
+		if n < 0 || n > len(body) {
+			return nil, fmt.Errorf("%w: section %v exceeds data", ErrFormat, i)
+		}
+		sections[i], body = body[:n], body[n:]
+	}
+	v.keys, v.offs, v.posts, v.voffs, v.vdata = sections[0][:nk], sections[1], sections[2], sections[3], body
+
+	if v.width == 0 || v.u32(v.offs, v.nkeys) != npost || v.u32(v.voffs, v.nvals) != len(v.vdata) {
+		return nil, ErrFormat
+	}
+	for i := 0; i < v.nkeys; i++ {
+		if v.u32(v.offs, i) > v.u32(v.offs, i+1) {
+			return nil, ErrFormat
+		}
+	}
+	for i := 0; i < v.nvals; i++ {
+		if v.u32(v.voffs, i) > v.u32(v.voffs, i+1) {
+			return nil, ErrFormat
+		}
+	}
+	for i := 0; i < npost; i++ {
+		if v.posting(i) >= v.nvals {
+			return nil, ErrFormat
+		}
+	}
+	return v, nil
+}
+
+// Len returns number of distinct values.
+func (v *View) Len() int { return v.nvals }
+
+// Match indexed values for x that meet min threshold; returns matches and unit scores,
+// ratio of trigrams of x matched.
+func (v *View) Match(x string, min float64) ([]string, []float64) {
+	var t tally[int]
+	q := v.Parse(x)
+	for _, s := range q {
+		i := sort.Search(v.nkeys, func(i int) bool { return string(v.key(i)) >= s })
+		if i < v.nkeys && string(v.key(i)) == s {
+			lo, hi := v.postings(i)
+			for j := lo; j < hi; j++ {
+				t.add(v.posting(j), 1)
+			}
+		}
+	}
+
+	p, u := t.filter(min, func(i int) float64 { return t.u[i] / float64(len(q)) })
+	m := make([]string, len(p))
+	for i, j := range p {
+		m[i] = string(v.value(j))
+	}
+	return m, u
+}
+
+func (v *View) key(i int) []byte { return v.keys[i*v.width : (i+1)*v.width] }
+
+// postings returns range of postings for key i.
+func (v *View) postings(i int) (lo, hi int) { return v.u32(v.offs, i), v.u32(v.offs, i+1) }
+
+func (v *View) posting(i int) int { return v.u32(v.posts, i) }
+
+func (v *View) value(i int) []byte { return v.vdata[v.u32(v.voffs, i):v.u32(v.voffs, i+1)] }
+
+func (v *View) u32(b []byte, i int) int { return int(binary.LittleEndian.Uint32(b[4*i:])) }
+
+// pad4 returns number of bytes needed to align n to four bytes.
+func pad4(n int) int { return (4 - n%4) % 4 }
@@ -0,0 +1,155 @@
+package trigram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	var gs Set
+	gs.Index([]string{"the quick", "red fox", "jumps over", "the lazy", "brown dog"}...)
+	gs.Index(big...)
+
+	var buf bytes.Buffer
+	n, err := gs.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(n) != buf.Len() {
+		t.Fatalf("WriteTo reported %v bytes, wrote %v", n, buf.Len())
+	}
+	data := buf.Bytes()
+
+	var rs Set
+	if _, err := rs.ReadFrom(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gs.ks, rs.ks) || !reflect.DeepEqual(gs.vs, rs.vs) {
+		t.Fatal("ReadFrom did not reproduce written Set")
+	}
+	if fmt.Sprint(gs.ls, gs.nt) != fmt.Sprint(rs.ls, rs.nt) {
+		t.Fatal("ReadFrom did not reproduce trigram counts of written Set")
+	}
+	if rs.Mapping == nil || rs.Fields == nil {
+		t.Fatal("ReadFrom did not initialize Set")
+	}
+
+	v, err := NewView(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := v.Len(), len(big)+5; have != want {
+		t.Fatalf("View.Len: have %v, want %v", have, want)
+	}
+	for _, q := range []string{"bog", "the", "abcd", "nothing", ""} {
+		wm, wu := gs.Match(q, 0.33)
+		hm, hu := v.Match(q, 0.33)
+		if fmt.Sprint(wm, wu) != fmt.Sprint(hm, hu) {
+			t.Fatalf("View.Match(%q)\nhave %q %v\nwant %q %v", q, hm, hu, wm, wu)
+		}
+	}
+}
+
+func TestFileN(t *testing.T) {
+	gs := Set{N: 2}
+	gs.Index("go", "fmt.Printf", "os")
+	var buf bytes.Buffer
+	if _, err := gs.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	v, err := NewView(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := v.Match("go", 1); fmt.Sprint(m) != "[go]" {
+		t.Fatalf("View.Match: have %v, want [go]", m)
+	}
+
+	var rs Set
+	if _, err := rs.ReadFrom(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := rs.Match("go", 1); rs.N != 2 || fmt.Sprint(m) != "[go]" {
+		t.Fatalf("ReadFrom: have N %v and %v, want N 2 and [go]", rs.N, m)
+	}
+	rs.Index("ls")
+	if _, err := rs.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := Set{N: 3}
+	if _, err := ts.ReadFrom(bytes.NewReader(data)); !errors.Is(err, ErrFormat) {
+		t.Fatalf("ReadFrom with N 3: have %v, want %v", err, ErrFormat)
+	}
+}
+
+func TestFileEmpty(t *testing.T) {
+	var gs Set
+	var buf bytes.Buffer
+	if _, err := gs.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewView(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := v.Match("abc", 0); len(m) != 0 {
+		t.Fatalf("unexpected matches %q", m)
+	}
+}
+
+func TestFileErrors(t *testing.T) {
+	var gs Set
+	gs.Index("the quick", "brown fox")
+	var buf bytes.Buffer
+	gs.WriteTo(&buf)
+
+	corrupt := func(fn func(b []byte)) []byte {
+		b := append([]byte(nil), buf.Bytes()...)
+		fn(b)
+		return b
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"short", buf.Bytes()[:10], ErrFormat},
+		{"magic", corrupt(func(b []byte) { b[0] = 'x' }), ErrFormat},
+		{"version", corrupt(func(b []byte) { binary.LittleEndian.PutUint32(b[4:], Version+1) }), ErrVersion},
+		{"checksum", corrupt(func(b []byte) { b[len(b)-1]++ }), ErrChecksum},
+		{"truncated", buf.Bytes()[:buf.Len()-1], ErrChecksum},
+	}
+	for _, tt := range tests {
+		if _, err := NewView(tt.data); !errors.Is(err, tt.err) {
+			t.Errorf("%s: have %v, want %v", tt.name, err, tt.err)
+		}
+		var rs Set
+		if _, err := rs.ReadFrom(bytes.NewReader(tt.data)); !errors.Is(err, tt.err) {
+			t.Errorf("%s: ReadFrom have %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func BenchmarkViewMatch(b *testing.B) {
+	var gs Set
+	gs.Index(big...)
+	var buf bytes.Buffer
+	gs.WriteTo(&buf)
+	v, err := NewView(buf.Bytes())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		v.Match("abcd", 0.33)
+	}
+}
@@ -0,0 +1,46 @@
+package trigram
+
+// Doc is text indexed by a caller defined ID, such as an index of the caller's own records.
+type Doc struct {
+	ID   uint64
+	Text string
+}
+
+// IDSet indexer storing document IDs instead of text; zero value is valid.
+type IDSet struct {
+	Parser
+
+	// Scorer used when calling Match
+	// or defaults to Unit if not set.
+	Scorer Scorer
+
+	postings[uint64]
+}
+
+// Index parses and stores trigrams for each doc text by doc ID. Text indexed
+// under an ID already in use is added to trigrams of that ID.
+func (a *IDSet) Index(docs ...Doc) {
+	a.init()
+	for _, d := range docs {
+		a.insert(d.ID, a.Parse(d.Text))
+	}
+}
+
+// Remove id from postings of every trigram, dropping trigrams left without IDs;
+// returns true if id was indexed.
+func (a *IDSet) Remove(id uint64) bool {
+	return a.removeAll(id)
+}
+
+// Update replaces trigrams of doc ID with those of doc text; returns true if doc ID was indexed.
+func (a *IDSet) Update(doc Doc) bool {
+	ok := a.Remove(doc.ID)
+	a.Index(doc)
+	return ok
+}
+
+// Match indexed IDs for x that meet min threshold; returns matches in ascending order and scores.
+// Scores are unit scores, ratio of trigrams of x matched, unless Scorer is set.
+func (a IDSet) Match(x string, min float64) ([]uint64, []float64) {
+	return a.match(a.Parse(x), min, a.Scorer)
+}
@@ -0,0 +1,64 @@
+package trigram
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIDSet(t *testing.T) {
+	terms := []string{"the quick", "red fox", "jumps over", "the lazy", "brown dog"}
+
+	var gs Set
+	gs.Index(terms...)
+
+	var ids IDSet
+	for i, s := range terms {
+		ids.Index(Doc{ID: uint64(100 + i), Text: s})
+	}
+	if len(ids.ks) != len(gs.ks) {
+		t.Fatalf("Unexpected keys, have %v, want %v", len(ids.ks), len(gs.ks))
+	}
+
+	for _, q := range []string{"bog", "the", "quick fox", "nothing"} {
+		sm, su := gs.Match(q, 0.33)
+		im, iu := ids.Match(q, 0.33)
+
+		want := make(map[string]float64)
+		for i, s := range sm {
+			want[s] = su[i]
+		}
+		have := make(map[string]float64)
+		for i, id := range im {
+			have[terms[id-100]] = iu[i]
+		}
+		if fmt.Sprint(have) != fmt.Sprint(want) {
+			t.Fatalf("Match(%q): have %v, want %v", q, have, want)
+		}
+	}
+}
+
+func TestIDSetRemove(t *testing.T) {
+	var ids IDSet
+	ids.Index(Doc{1, "brown dog"}, Doc{2, "red fox"}, Doc{3, "lazy dog"})
+	if !ids.Remove(1) || ids.Remove(1) {
+		t.Fatal("Remove reported wrong result")
+	}
+	if m, _ := ids.Match("dog", 1); fmt.Sprint(m) != "[3]" {
+		t.Fatalf("Match after Remove: have %v, want [3]", m)
+	}
+	if !ids.Update(Doc{2, "red dog"}) {
+		t.Fatal("Update reported wrong result")
+	}
+	if m, _ := ids.Match("dog", 1); fmt.Sprint(m) != "[2 3]" {
+		t.Fatalf("Match after Update: have %v, want [2 3]", m)
+	}
+	if m, _ := ids.Match("fox", 0.5); len(m) != 0 {
+		t.Fatalf("Match after Update returned old text: %v", m)
+	}
+
+	var want IDSet
+	want.Index(Doc{2, "red dog"}, Doc{3, "lazy dog"})
+	if fmt.Sprint(want.ks, want.vs) != fmt.Sprint(ids.ks, ids.vs) {
+		t.Fatalf("have %q %v\nwant %q %v", ids.ks, ids.vs, want.ks, want.vs)
+	}
+}
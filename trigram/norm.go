@@ -0,0 +1,71 @@
+package trigram
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization functions for use as Parser.Normalize.
+//
+// Parse applies Normalize to an entire string before Mapping so that sequences of runes,
+// such as a letter followed by a combining mark, may be normalized together. RegexpQuery
+// applies Normalize to each rune of a regular expression, and so matches all values unless
+// Normalize of a string equals Normalize of each of its runes joined, as with Fold; NFC and
+// NFKC compose runes, and NFD and NFKD reorder combining marks, so do not.
+
+// NFC returns s in Unicode Normalization Form C, canonical composition.
+func NFC(s string) string { return norm.NFC.String(s) }
+
+// NFD returns s in Unicode Normalization Form D, canonical decomposition.
+func NFD(s string) string { return norm.NFD.String(s) }
+
+// NFKC returns s in Unicode Normalization Form KC, compatibility composition.
+func NFKC(s string) string { return norm.NFKC.String(s) }
+
+// NFKD returns s in Unicode Normalization Form KD, compatibility decomposition.
+func NFKD(s string) string { return norm.NFKD.String(s) }
+
+// StripMarks returns canonical decomposition of s with nonspacing marks removed,
+// such as "café" to "cafe".
+func StripMarks(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)))
+	r, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return r
+}
+
+// CaseFold returns s with Unicode full case folding, such as "Straße" to "strasse".
+func CaseFold(s string) string { return cases.Fold().String(s) }
+
+// translit are replacements of latin letters without decompositions.
+var translit = strings.NewReplacer(
+	"Æ", "AE", "æ", "ae", "Œ", "OE", "œ", "oe", "Ø", "O", "ø", "o",
+	"Ł", "L", "ł", "l", "Đ", "D", "đ", "d", "Ð", "D", "ð", "d",
+	"Þ", "TH", "þ", "th", "ß", "ss", "ẞ", "SS", "ı", "i", "Ħ", "H", "ħ", "h",
+	"Ŧ", "T", "ŧ", "t", "Ŀ", "L", "ŀ", "l",
+)
+
+// Transliterate returns s with latin letters that have no decomposition replaced
+// by ASCII letters, such as "Øresund" to "Oresund" and "æble" to "aeble".
+func Transliterate(s string) string { return translit.Replace(s) }
+
+// Pipeline returns a function that applies each of fns in order.
+func Pipeline(fns ...func(string) string) func(string) string {
+	return func(s string) string {
+		for _, fn := range fns {
+			s = fn(s)
+		}
+		return s
+	}
+}
+
+// Fold applies compatibility decomposition, strips marks, transliterates and case folds s
+// for diacritic and case insensitive matching.
+var Fold = Pipeline(NFKD, StripMarks, Transliterate, CaseFold)
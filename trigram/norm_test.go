@@ -0,0 +1,51 @@
+package trigram
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		fn      func(string) string
+		in, out string
+	}{
+		{NFC, "cafe\u0301", "café"},
+		{NFD, "café", "cafe\u0301"},
+		{NFKC, "ﬁne", "fine"},
+		{NFKD, "①", "1"},
+		{StripMarks, "café cafe\u0301", "cafe cafe"},
+		{StripMarks, "\uac00", "\u1100\u1161"},
+		{CaseFold, "Straße", "strasse"},
+		{Transliterate, "Øresund æble", "Oresund aeble"},
+		{Fold, "Ｃａｆé ŁÓDŹ Œuvre", "cafe lodz oeuvre"},
+	}
+	for _, tt := range tests {
+		if have := tt.fn(tt.in); have != tt.out {
+			t.Errorf("have %q, want %q", have, tt.out)
+		}
+	}
+}
+
+func TestSetNormalize(t *testing.T) {
+	// precomposed and combining accent parse differently without Normalize.
+	var p Parser
+	if fmt.Sprint(p.Parse("café")) == fmt.Sprint(p.Parse("cafe\u0301")) {
+		t.Fatal("expected different trigrams without Normalize")
+	}
+
+	p.Normalize = Fold
+	for _, s := range []string{"cafe\u0301", "CAFÉ", "Café"} {
+		if have, want := p.Parse(s), p.Parse("cafe"); fmt.Sprint(have) != fmt.Sprint(want) {
+			t.Fatalf("Parse(%q): have %q, want %q", s, have, want)
+		}
+	}
+
+	gs := Set{Normalize: Fold}
+	gs.Index("Café Müller", "Straße", "naïve")
+	for q, want := range map[string]string{"cafe muller": "[Café Müller]", "STRASSE": "[Straße]", "naive": "[naïve]"} {
+		if m, _ := gs.Match(q, 1); fmt.Sprint(m) != want {
+			t.Fatalf("Match(%q): have %v, want %v", q, m, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package trigram
+
+import "strings"
+
+// Ranker re-ranks matches by edit distance from a query; zero value is valid and
+// sorts matches by descending score.
+type Ranker struct {
+	// Parser used to normalize and map query and matches before measuring distance;
+	// fields are not split.
+	Parser
+
+	// MaxDistance of matches kept or unlimited if not positive.
+	MaxDistance int
+
+	// Weight of edit similarity in combined score, from 0 to 1; the remainder weighs
+	// match score. Edit similarity is one less distance over rune length of the longer string.
+	Weight float64
+
+	// PrefixBoost added to combined score of matches prefixed by the query.
+	PrefixBoost float64
+}
+
+// Rank returns matches m and scores u for query x with combined scores, in order of
+// descending score; matches beyond MaxDistance are dropped. Modifies m and u in place.
+func (r Ranker) Rank(x string, m []string, u []float64) ([]string, []float64) {
+	r.init()
+	q := r.form(x)
+	max := -1
+	if r.MaxDistance > 0 {
+		max = r.MaxDistance
+	}
+	fm, fu := m[:0], u[:0]
+	for i, s := range m {
+		v := r.form(s)
+		d := distance(q, v, max)
+		if max >= 0 && d > max {
+			continue
+		}
+		w := (1 - r.Weight) * u[i]
+		if n := maxInt(len(q), len(v)); n > 0 {
+			w += r.Weight * (1 - float64(d)/float64(n))
+		} else {
+			w += r.Weight
+		}
+		if strings.HasPrefix(s, x) {
+			w += r.PrefixBoost
+		}
+		fm, fu = append(fm, s), append(fu, w)
+	}
+	return Top(fm, fu, 0)
+}
+
+// form returns runes of s normalized and mapped.
+func (r Ranker) form(s string) []rune {
+	if r.Normalize != nil {
+		s = r.Normalize(s)
+	}
+	return []rune(strings.Map(r.Mapping, s))
+}
+
+// Distance returns Damerau-Levenshtein distance between runes of a and b, the count of
+// insertions, deletions, substitutions and transpositions of adjacent runes to change a
+// to b, where no substring is edited more than once; known as optimal string alignment.
+func Distance(a, b string) int {
+	if a == b {
+		return 0
+	}
+	return distance([]rune(a), []rune(b), -1)
+}
+
+// distance returns optimal string alignment distance between a and b, or max+1 if
+// greater than max and max is not negative.
+func distance(a, b []rune, max int) int {
+	if max >= 0 && absInt(len(a)-len(b)) > max {
+		return max + 1
+	}
+	p2, p1, p0 := make([]int, len(b)+1), make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range p1 {
+		p1[j] = j
+	}
+	lo1 := 0
+	for i := 1; i <= len(a); i++ {
+		p0[0] = i
+		lo := i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d := minInt(minInt(p1[j]+1, p0[j-1]+1), p1[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d = minInt(d, p2[j-2]+1)
+			}
+			p0[j] = d
+			lo = minInt(lo, d)
+		}
+		// transpositions skip at most one row, so distance exceeds max
+		// if every value of two consecutive rows does.
+		if max >= 0 && lo > max && lo1 > max {
+			return max + 1
+		}
+		lo1 = lo
+		p2, p1, p0 = p1, p0, p2
+	}
+	if d := p1[len(b)]; max < 0 || d <= max {
+		return d
+	}
+	return max + 1
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
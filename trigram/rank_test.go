@@ -0,0 +1,69 @@
+package trigram
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "abc", 0},
+		{"ca", "ac", 1},
+		{"abcd", "acbd", 1},
+		{"kitten", "sitting", 3},
+		{"café", "cafe", 1},
+		{"ca", "abc", 3}, // optimal string alignment does not edit "ac" again
+		{"printf", "sprintf", 1},
+	}
+	for _, tt := range tests {
+		if have := Distance(tt.a, tt.b); have != tt.want {
+			t.Errorf("Distance(%q, %q): have %v, want %v", tt.a, tt.b, have, tt.want)
+		}
+		if have := Distance(tt.b, tt.a); have != tt.want {
+			t.Errorf("Distance(%q, %q): have %v, want %v", tt.b, tt.a, have, tt.want)
+		}
+	}
+
+	for n := 0; n < 2000; n++ {
+		a, b := []rune(words[rand.Intn(len(words))]), []rune(words[rand.Intn(len(words))])
+		d := distance(a, b, -1)
+		for max := 0; max < 8; max++ {
+			want := d
+			if want > max {
+				want = max + 1
+			}
+			if have := distance(a, b, max); have != want {
+				t.Fatalf("distance(%q, %q, %v): have %v, want %v", string(a), string(b), max, have, want)
+			}
+		}
+	}
+}
+
+func TestRanker(t *testing.T) {
+	var gs Set
+	gs.Index("fmt.Printf", "fmt.Sprintf", "fmt.Println", "log.Printf", "fmt.Fprintf")
+
+	m, u := gs.Match("printf", 0.33)
+	m, _ = Ranker{Weight: 0.5}.Rank("printf", m, u)
+	if have, want := fmt.Sprint(m), "[fmt.Printf log.Printf fmt.Fprintf fmt.Sprintf fmt.Println]"; have != want {
+		t.Fatalf("Rank: have %v, want %v", have, want)
+	}
+
+	m, u = gs.Match("printf", 0.33)
+	m, _ = Ranker{MaxDistance: 3}.Rank("printf", m, u)
+	if have, want := fmt.Sprint(m), "[fmt.Printf log.Printf]"; have != want {
+		t.Fatalf("Rank with MaxDistance: have %v, want %v", have, want)
+	}
+
+	m, u = gs.Match("fmt.P", 0.33)
+	m, _ = Ranker{PrefixBoost: 1}.Rank("fmt.P", m, u)
+	if have, want := fmt.Sprint(m[:2]), "[fmt.Printf fmt.Println]"; have != want {
+		t.Fatalf("Rank with PrefixBoost: have %v, want %v", have, want)
+	}
+}
@@ -0,0 +1,479 @@
+package trigram
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+
+	"dasa.cc/x/set"
+)
+
+// QueryOp is an operator of a Query.
+type QueryOp int
+
+const (
+	QAll  QueryOp = iota // everything matches
+	QNone                // nothing matches
+	QAnd                 // all of trigrams and subqueries match
+	QOr                  // any of trigrams and subqueries match
+)
+
+// Query is a boolean query of trigrams.
+type Query struct {
+	Op      QueryOp
+	Trigram set.Slice[string]
+	Sub     []*Query
+}
+
+var (
+	queryAll  = &Query{Op: QAll}
+	queryNone = &Query{Op: QNone}
+)
+
+// String returns trigrams quoted, separated by space for QAnd or bar for QOr,
+// with subqueries in parentheses; QAll is "+" and QNone is "-".
+func (q *Query) String() string {
+	switch q.Op {
+	case QAll:
+		return "+"
+	case QNone:
+		return "-"
+	}
+	sep := " "
+	if q.Op == QOr {
+		sep = "|"
+	}
+	var p []string
+	for _, t := range q.Trigram {
+		p = append(p, fmt.Sprintf("%q", t))
+	}
+	for _, s := range q.Sub {
+		if len(q.Trigram)+len(q.Sub) > 1 && (s.Op == QAnd || s.Op == QOr) {
+			p = append(p, "("+s.String()+")")
+		} else {
+			p = append(p, s.String())
+		}
+	}
+	return strings.Join(p, sep)
+}
+
+// and returns query matching both a and b.
+func (a *Query) and(b *Query) *Query { return a.join(QAnd, b) }
+
+// or returns query matching either a or b.
+func (a *Query) or(b *Query) *Query { return a.join(QOr, b) }
+
+// join a and b with op, QAnd or QOr, simplifying where possible.
+func (a *Query) join(op QueryOp, b *Query) *Query {
+	// identity and absorbing elements of op.
+	id, abs := queryAll, queryNone
+	if op == QOr {
+		id, abs = queryNone, queryAll
+	}
+	switch {
+	case a.Op == abs.Op || b.Op == abs.Op:
+		return abs
+	case a.Op == id.Op:
+		return b
+	case b.Op == id.Op:
+		return a
+	}
+
+	q := &Query{Op: op}
+	for _, x := range []*Query{a, b} {
+		if x.Op == op {
+			q.Trigram.Union(x.Trigram)
+			q.Sub = append(q.Sub, x.Sub...)
+		} else if len(x.Trigram) == 1 && len(x.Sub) == 0 {
+			q.Trigram.Union(x.Trigram)
+		} else {
+			q.Sub = append(q.Sub, x)
+		}
+	}
+	return q
+}
+
+// RegexpQuery returns a query of grams that indexed values matching re must have.
+// Values matched by the query are a superset of those matched by re. If Normalize
+// does not normalize a string rune by rune, such as NFC, the query matches all values.
+func (p Parser) RegexpQuery(re *syntax.Regexp) *Query {
+	p.init()
+	if !p.runewise() {
+		return queryAll
+	}
+	x := p.analyze(re)
+	x.addExact(p)
+	return x.match
+}
+
+// Regexp returns indexed values that may match regular expression expr as found
+// by RegexpQuery, or if verify, only those that match expr.
+func (a Set) Regexp(expr string, verify bool) ([]string, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	vs := a.query(a.Parser().RegexpQuery(re))
+	if !verify {
+		return vs, nil
+	}
+	rx, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	fs := vs[:0]
+	for _, s := range vs {
+		if rx.MatchString(s) {
+			fs = append(fs, s)
+		}
+	}
+	return fs, nil
+}
+
+// Query returns indexed values matched by q in ascending order.
+func (a Set) Query(q *Query) []string { return a.query(q) }
+
+// Query returns indexed IDs matched by q in ascending order.
+func (a IDSet) Query(q *Query) []uint64 { return a.query(q) }
+
+// Regexp returns indexed IDs that may match regular expression expr as found by RegexpQuery.
+func (a IDSet) Regexp(expr string) ([]uint64, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	return a.query(a.RegexpQuery(re)), nil
+}
+
+// query returns new slice of values matched by q.
+func (a postings[T]) query(q *Query) set.Slice[T] {
+	switch q.Op {
+	case QAll:
+		return append(set.Slice[T](nil), a.ls.Keys()...)
+	case QNone:
+		return nil
+	}
+
+	var is []int
+	for _, t := range q.Trigram {
+		if i := a.ks.Index(t); i != -1 {
+			is = append(is, i)
+		} else if q.Op == QAnd {
+			return nil
+		}
+	}
+
+	if q.Op == QOr {
+		r := a.vs.Union(is...)
+		for _, s := range q.Sub {
+			r.Union(a.query(s))
+		}
+		return r
+	}
+
+	var r set.Slice[T]
+	if len(is) == 0 && len(q.Sub) == 0 {
+		return a.query(queryAll)
+	} else if len(is) > 0 {
+		r = a.vs.Intersect(is...)
+	} else {
+		r = a.query(q.Sub[0])
+		q = &Query{Op: QAnd, Sub: q.Sub[1:]}
+	}
+	for _, s := range q.Sub {
+		if len(r) == 0 {
+			break
+		}
+		r.Intersect(a.query(s))
+	}
+	return r
+}
+
+// Limits of string sets in regexp analysis; beyond which sets are reduced to queries.
+const (
+	maxExact = 16 // strings in exact set
+	maxSet   = 32 // strings in prefix or suffix set
+	maxClass = 8  // runes of a character class to enumerate
+	maxLen   = 16 // bytes of a string in exact set
+)
+
+// info of a regular expression; strings are as modified by a Parser's mapping function.
+type info struct {
+	empty  bool              // matches empty string
+	exact  set.Slice[string] // strings matched exactly if not nil
+	prefix set.Slice[string] // prefixes of strings matched if exact is nil
+	suffix set.Slice[string] // suffixes of strings matched if exact is nil
+	match  *Query            // query of strings matched
+}
+
+// anyMatch returns info of any string.
+func anyMatch() info {
+	return info{empty: true, prefix: []string{""}, suffix: []string{""}, match: queryAll}
+}
+
+// anyChar returns info of any single rune.
+func anyChar() info {
+	return info{prefix: []string{""}, suffix: []string{""}, match: queryAll}
+}
+
+// emptyInfo returns info of the empty string.
+func emptyInfo() info {
+	return info{empty: true, exact: []string{""}, match: queryAll}
+}
+
+// analyze returns info of strings matched by re.
+func (p Parser) analyze(re *syntax.Regexp) info {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return info{match: queryNone}
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine,
+		syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return emptyInfo()
+	case syntax.OpLiteral:
+		x := emptyInfo()
+		for _, r := range re.Rune {
+			rs := []rune{r}
+			if re.Flags&syntax.FoldCase != 0 {
+				for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+					rs = append(rs, f)
+				}
+			}
+			x = p.concat(x, p.runes(rs))
+		}
+		return x
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		return anyChar()
+	case syntax.OpCharClass:
+		var rs []rune
+		for i := 0; i < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if rs = append(rs, r); len(rs) > maxClass {
+					return anyChar()
+				}
+			}
+		}
+		if len(rs) == 0 {
+			return info{match: queryNone}
+		}
+		return p.runes(rs)
+	case syntax.OpCapture:
+		return p.analyze(re.Sub[0])
+	case syntax.OpStar:
+		return anyMatch()
+	case syntax.OpQuest:
+		return p.alternate(p.analyze(re.Sub[0]), emptyInfo())
+	case syntax.OpPlus:
+		return p.plus(p.analyze(re.Sub[0]))
+	case syntax.OpRepeat:
+		switch {
+		case re.Min == 0 && re.Max == 1:
+			return p.alternate(p.analyze(re.Sub[0]), emptyInfo())
+		case re.Min == 0:
+			return anyMatch()
+		case re.Min == 1 && re.Max == 1:
+			return p.analyze(re.Sub[0])
+		}
+		return p.plus(p.analyze(re.Sub[0]))
+	case syntax.OpConcat:
+		x := emptyInfo()
+		for _, s := range re.Sub {
+			x = p.concat(x, p.analyze(s))
+		}
+		return x
+	case syntax.OpAlternate:
+		x := p.analyze(re.Sub[0])
+		for _, s := range re.Sub[1:] {
+			x = p.alternate(x, p.analyze(s))
+		}
+		return x
+	}
+	return anyMatch()
+}
+
+// runewise reports whether Normalize of strings that compose or reorder runes under
+// Unicode normalization equals Normalize of each of their runes joined.
+func (p Parser) runewise() bool {
+	if p.Normalize == nil {
+		return true
+	}
+	for _, s := range []string{"e\u0301", "\u1100\u1161", "a\u0301\u0323"} {
+		var b strings.Builder
+		for _, r := range s {
+			b.WriteString(p.Normalize(string(r)))
+		}
+		if p.Normalize(s) != b.String() {
+			return false
+		}
+	}
+	return true
+}
+
+// runes returns exact info of any one of rs, each normalized and mapped.
+func (p Parser) runes(rs []rune) info {
+	x := info{match: queryAll}
+	for _, r := range rs {
+		s := string(r)
+		if p.Normalize != nil {
+			s = p.Normalize(s)
+		}
+		s = strings.Map(p.Mapping, s)
+		x.exact.Insert(s)
+		x.empty = x.empty || s == ""
+	}
+	return x
+}
+
+// concat returns info of x followed by y.
+func (p Parser) concat(x, y info) info {
+	z := info{empty: x.empty && y.empty, match: x.match.and(y.match)}
+	if x.exact != nil && y.exact != nil && len(x.exact)*len(y.exact) <= maxExact {
+		z.exact = cross(x.exact, y.exact)
+		z.simplify(p)
+		return z
+	}
+
+	xs, yp := x.suffix, y.prefix
+	if x.exact != nil {
+		xs = x.exact
+		z.prefix = cross(x.exact, y.prefix)
+	} else {
+		z.prefix = x.prefix
+		if x.empty {
+			z.prefix = set.Union(z.prefix, y.prefix)
+		}
+	}
+	if y.exact != nil {
+		yp = y.exact
+		z.suffix = cross(x.suffix, y.exact)
+	} else {
+		z.suffix = y.suffix
+		if y.empty {
+			z.suffix = set.Union(z.suffix, x.suffix)
+		}
+	}
+	if x.exact != nil && y.exact != nil {
+		// exact sets too large to cross; keep their trigrams.
+		z.match = z.match.and(p.any(x.exact)).and(p.any(y.exact))
+		z.prefix, z.suffix = head(x.exact, p.N-1), tail(y.exact, p.N-1)
+	}
+
+	// trigrams spanning boundary of x and y.
+	if len(xs)*len(yp) <= maxSet {
+		z.match = z.match.and(p.any(cross(xs, yp)))
+	}
+	z.simplify(p)
+	return z
+}
+
+// alternate returns info of x or y.
+func (p Parser) alternate(x, y info) info {
+	z := info{empty: x.empty || y.empty}
+	if x.exact != nil && y.exact != nil {
+		z.exact = set.Union(x.exact, y.exact)
+		z.match = x.match.or(y.match)
+		z.simplify(p)
+		return z
+	}
+	x.addExact(p)
+	y.addExact(p)
+	z.prefix = set.Union(x.prefix, y.prefix)
+	z.suffix = set.Union(x.suffix, y.suffix)
+	z.match = x.match.or(y.match)
+	z.simplify(p)
+	return z
+}
+
+// plus returns info of one or more of x.
+func (p Parser) plus(x info) info {
+	x.addExact(p)
+	return x
+}
+
+// addExact moves trigrams of exact strings into match, keeping exact as prefix and suffix.
+func (x *info) addExact(p Parser) {
+	if x.exact == nil {
+		return
+	}
+	x.match = x.match.and(p.any(x.exact))
+	x.prefix, x.suffix, x.exact = head(x.exact, p.N-1), tail(x.exact, p.N-1), nil
+}
+
+// simplify reduces sets beyond limits, moving their trigrams into match.
+func (x *info) simplify(p Parser) {
+	if x.exact != nil {
+		long := false
+		for _, s := range x.exact {
+			long = long || len(s) > maxLen
+		}
+		if len(x.exact) > maxExact || long {
+			x.addExact(p)
+		}
+		return
+	}
+	x.match = x.match.and(p.any(x.prefix)).and(p.any(x.suffix))
+	x.prefix, x.suffix = head(x.prefix, p.N-1), tail(x.suffix, p.N-1)
+}
+
+// any returns query matching trigrams of any of ss.
+func (p Parser) any(ss []string) *Query {
+	q := queryNone
+	for _, s := range ss {
+		q = q.or(p.all(s))
+	}
+	return q
+}
+
+// all returns query matching all grams within words of s, excluding padded grams
+// since s may be part of a longer word.
+func (p Parser) all(s string) *Query {
+	q := queryAll
+	for _, w := range strings.FieldsFunc(s, p.Fields) {
+		for i := 0; i+p.N <= len(w); i++ {
+			q = q.and(&Query{Op: QAnd, Trigram: []string{w[i : i+p.N]}})
+		}
+	}
+	return q
+}
+
+// cross returns each x of xs followed by each y of ys.
+func cross(xs, ys set.Slice[string]) set.Slice[string] {
+	var z set.Slice[string]
+	for _, x := range xs {
+		for _, y := range ys {
+			z.Insert(x + y)
+		}
+	}
+	return z
+}
+
+// head returns first n bytes of each of ss, or only the empty string if beyond maxSet.
+func head(ss set.Slice[string], n int) set.Slice[string] {
+	var z set.Slice[string]
+	for _, s := range ss {
+		if len(s) > n {
+			s = s[:n]
+		}
+		z.Insert(s)
+	}
+	if len(z) > maxSet {
+		return []string{""}
+	}
+	return z
+}
+
+// tail returns last n bytes of each of ss, or only the empty string if beyond maxSet.
+func tail(ss set.Slice[string], n int) set.Slice[string] {
+	var z set.Slice[string]
+	for _, s := range ss {
+		if len(s) > n {
+			s = s[len(s)-n:]
+		}
+		z.Insert(s)
+	}
+	if len(z) > maxSet {
+		return []string{""}
+	}
+	return z
+}
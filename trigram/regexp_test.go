@@ -0,0 +1,127 @@
+package trigram
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestRegexpQuery(t *testing.T) {
+	tests := []struct{ expr, want string }{
+		{`abc`, `"abc"`},
+		{`Printf`, `"int" "ntf" "pri" "rin"`},
+		{`(?i)ABCD`, `"abc" "bcd"`},
+		{`abc|xyz`, `"abc"|"xyz"`},
+		{`(abc|xyz)def`, `("abc" "bcd" "cde" "def")|("def" "xyz" "yzd" "zde")`},
+		{`a.c`, `+`},
+		{`abc.*def`, `"abc" "def"`},
+		{`fmt\.Print`, `"fmt" "int" "mtp" "pri" "rin" "tpr"`},
+		{`quick brown`, `"bro" "ick" "own" "qui" "row" "uic"`},
+		{`b[aeiou]g`, `"bag"|"beg"|"big"|"bog"|"bug"`},
+		{`ab+c`, `+`},
+		{`(abc)+`, `"abc"`},
+		{`x*`, `+`},
+		{`[^a]bcd`, `"bcd"`},
+		{`[^\x00-\x{10FFFF}]`, `-`},
+	}
+	var p Parser
+	for _, tt := range tests {
+		re, err := syntax.Parse(tt.expr, syntax.Perl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := p.RegexpQuery(re).String(); have != tt.want {
+			t.Errorf("RegexpQuery(%q): have %s, want %s", tt.expr, have, tt.want)
+		}
+	}
+}
+
+func TestRegexp(t *testing.T) {
+	terms := append([]string{
+		"fmt.Printf", "fmt.Sprintf", "fmt.Println", "the quick brown fox",
+		"jumps over", "the lazy dog", "Café", "cafe\u0301 au lait", "big bag", "aab", "aaab",
+	}, words[:500]...)
+
+	exprs := []string{
+		`Printf`, `(?i)PRINT`, `fmt\.S?print`, `Sprint[fl]?n?`, `quick brown`, `qu.ck`,
+		`^the`, `fox$`, `(red|brown) (fox|dog)`, `b[aeiou]g`, `\bjumps?\b`, `x*`,
+		`a{2,3}b`, `ab?c`, `[a-z]+`, `caf.`, `(?i)café`, `eta+o`, `(th|sh|ch)e[a-z]`,
+		`(tion|sion)$`, `ee.*ee`, `[et][at][ao]i`, `cafe\x{301}`, `cafe`, `caf\x{e9}`,
+	}
+	for _, p := range []Parser{{}, {N: 2}, {N: 4, Padding: NoPadding}, {Normalize: Fold}, {Normalize: NFC}, {Normalize: NFD}} {
+		var gs Set
+		gs.setParser(p)
+		gs.Index(terms...)
+		testRegexp(t, gs, exprs)
+	}
+}
+
+func testRegexp(t *testing.T, gs Set, exprs []string) {
+	t.Helper()
+	for _, expr := range exprs {
+		rx := regexp.MustCompile(expr)
+		var want []string
+		for _, s := range gs.Query(queryAll) {
+			if rx.MatchString(s) {
+				want = append(want, s)
+			}
+		}
+
+		cand, err := gs.Regexp(expr, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have := make(map[string]bool)
+		for _, s := range cand {
+			have[s] = true
+		}
+		for _, s := range want {
+			if !have[s] {
+				t.Fatalf("N=%v Regexp(%q) missing candidate %q", gs.N, expr, s)
+			}
+		}
+
+		got, err := gs.Regexp(expr, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("Regexp(%q, true): have %v, want %v", expr, got, want)
+		}
+	}
+
+	if _, err := gs.Regexp(`a(b`, false); err == nil {
+		t.Fatal("expected error for invalid expression")
+	}
+}
+
+func TestRegexpNormalize(t *testing.T) {
+	gs := Set{Normalize: NFC}
+	gs.Index("cafe\u0301", "tea")
+	for _, expr := range []string{"cafe\u0301", "cafe", "caf"} {
+		m, err := gs.Regexp(expr, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(m) != "[cafe\u0301]" {
+			t.Fatalf("Regexp(%q): have %q, want [cafe\u0301]", expr, m)
+		}
+	}
+
+	re, err := syntax.Parse("Printf", syntax.Perl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		p    Parser
+		want bool
+	}{
+		{Parser{}, false}, {Parser{Normalize: Fold}, false}, {Parser{Normalize: StripMarks}, false},
+		{Parser{Normalize: NFC}, true}, {Parser{Normalize: NFKC}, true}, {Parser{Normalize: NFD}, true},
+	} {
+		if have := tt.p.RegexpQuery(re).Op == QAll; have != tt.want {
+			t.Errorf("RegexpQuery matches all: have %v, want %v", have, tt.want)
+		}
+	}
+}
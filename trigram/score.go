@@ -0,0 +1,117 @@
+package trigram
+
+import (
+	"math"
+	"sort"
+)
+
+// Overlap of an indexed value with a query.
+type Overlap struct {
+	// Shared is weight sum of trigrams shared by query and value.
+	Shared float64
+
+	// Query is weight sum of query trigrams.
+	Query float64
+
+	// NShared, NQuery, and NValue count trigrams shared, of query, and of value.
+	NShared, NQuery, NValue int
+
+	// NMean is mean trigram count of indexed values.
+	NMean float64
+}
+
+// Scorer weighs trigrams of a query and scores values sharing trigrams with it.
+type Scorer interface {
+	// Weight returns weight of a query trigram found in df of n indexed values.
+	Weight(df, n int) float64
+
+	// Score returns score of a value by its overlap with a query.
+	Score(o Overlap) float64
+}
+
+// Unit scores values as ratio of query trigrams matched, each trigram of equal weight.
+type Unit struct{}
+
+func (Unit) Weight(df, n int) float64 { return 1 }
+
+func (Unit) Score(o Overlap) float64 { return o.Shared / o.Query }
+
+// IDF scores values as ratio of query trigrams matched, each trigram weighed by
+// inverse document frequency so rare trigrams count more than common ones.
+type IDF struct{}
+
+func (IDF) Weight(df, n int) float64 { return idf(df, n) }
+
+func (IDF) Score(o Overlap) float64 { return o.Shared / o.Query }
+
+// BM25 scores values as IDF does, additionally penalizing values with more trigrams
+// than the mean, such as long strings. Zero value is valid.
+type BM25 struct {
+	// K1 saturates effect of length normalization, or defaults to 1.2 if zero.
+	K1 float64
+
+	// B scales effect of value length, 0 for none and 1 for full, or defaults to 0.75 if zero.
+	B float64
+}
+
+func (BM25) Weight(df, n int) float64 { return idf(df, n) }
+
+func (a BM25) Score(o Overlap) float64 {
+	k1, b := a.K1, a.B
+	if k1 == 0 {
+		k1 = 1.2
+	}
+	if b == 0 {
+		b = 0.75
+	}
+	l := 1.0
+	if o.NMean > 0 {
+		l = float64(o.NValue) / o.NMean
+	}
+	return o.Shared / o.Query * (k1 + 1) / (1 + k1*(1-b+b*l))
+}
+
+// Jaccard scores values as size of trigram intersection over size of union with query.
+type Jaccard struct{}
+
+func (Jaccard) Weight(df, n int) float64 { return 1 }
+
+func (Jaccard) Score(o Overlap) float64 {
+	return float64(o.NShared) / float64(o.NQuery+o.NValue-o.NShared)
+}
+
+// Dice scores values as twice size of trigram intersection over sum of sizes with query.
+type Dice struct{}
+
+func (Dice) Weight(df, n int) float64 { return 1 }
+
+func (Dice) Score(o Overlap) float64 {
+	return 2 * float64(o.NShared) / float64(o.NQuery+o.NValue)
+}
+
+// idf returns inverse document frequency of a trigram found in df of n values; always positive.
+func idf(df, n int) float64 {
+	return math.Log(1 + (float64(n-df)+0.5)/(float64(df)+0.5))
+}
+
+// Top sorts matches in place by descending score, ties in ascending order of match,
+// and returns at most k of them if k > 0.
+func Top[T any](m []T, u []float64, k int) ([]T, []float64) {
+	sort.Stable(byScore[T]{m, u})
+	if k > 0 && k < len(m) {
+		m, u = m[:k], u[:k]
+	}
+	return m, u
+}
+
+type byScore[T any] struct {
+	m []T
+	u []float64
+}
+
+func (a byScore[T]) Len() int           { return len(a.m) }
+func (a byScore[T]) Less(i, j int) bool { return a.u[i] > a.u[j] }
+func (a byScore[T]) Swap(i, j int) {
+	a.m[i], a.m[j] = a.m[j], a.m[i]
+	a.u[i], a.u[j] = a.u[j], a.u[i]
+}
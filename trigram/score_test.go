@@ -0,0 +1,115 @@
+package trigram
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func scores(gs Set, x string) map[string]float64 {
+	m, u := gs.Match(x, 0)
+	r := make(map[string]float64)
+	for i, s := range m {
+		r[s] = u[i]
+	}
+	return r
+}
+
+func TestScorerUnit(t *testing.T) {
+	var gs Set
+	gs.Index("the cat", "the dog", "a dog")
+	want := fmt.Sprint(scores(gs, "the dog"))
+	gs.Scorer = Unit{}
+	if have := fmt.Sprint(scores(gs, "the dog")); have != want {
+		t.Fatalf("have %v, want %v", have, want)
+	}
+}
+
+func TestScorerIDF(t *testing.T) {
+	var gs Set
+	gs.Index("the cat", "the dog", "the cow", "a dog")
+
+	u := scores(gs, "the dog")
+	if u["a dog"] != u["the cat"] {
+		t.Fatalf("Unit scores differ: %v", u)
+	}
+
+	gs.Scorer = IDF{}
+	u = scores(gs, "the dog")
+	if !(u["a dog"] > u["the cat"]) {
+		t.Fatalf("IDF did not weigh rare trigrams higher: %v", u)
+	}
+	if u["the dog"] != 1 {
+		t.Fatalf("IDF exact match: have %v, want 1", u["the dog"])
+	}
+}
+
+func TestScorerBM25(t *testing.T) {
+	var gs Set
+	gs.Index("dog", "dog with a very long tail indeed", "cat")
+
+	u := scores(gs, "dog")
+	if u["dog"] != u["dog with a very long tail indeed"] {
+		t.Fatalf("Unit scores differ: %v", u)
+	}
+
+	gs.Scorer = BM25{}
+	u = scores(gs, "dog")
+	if !(u["dog"] > u["dog with a very long tail indeed"]) {
+		t.Fatalf("BM25 did not penalize long value: %v", u)
+	}
+
+	gs.Scorer = BM25{B: 1e-9}
+	u = scores(gs, "dog")
+	if d := u["dog"] - u["dog with a very long tail indeed"]; math.Abs(d) > 1e-6 {
+		t.Fatalf("BM25 without length normalization differs by %v: %v", d, u)
+	}
+}
+
+func TestScorerSimilarity(t *testing.T) {
+	var gs Set
+	gs.Index("dog", "dogs")
+
+	gs.Scorer = Jaccard{}
+	if have, want := fmt.Sprint(scores(gs, "dog")), fmt.Sprint(map[string]float64{"dog": 1, "dogs": 3.0 / 6}); have != want {
+		t.Fatalf("Jaccard: have %v, want %v", have, want)
+	}
+	gs.Scorer = Dice{}
+	if have, want := fmt.Sprint(scores(gs, "dog")), fmt.Sprint(map[string]float64{"dog": 1, "dogs": 6.0 / 9}); have != want {
+		t.Fatalf("Dice: have %v, want %v", have, want)
+	}
+}
+
+func TestScorerCounts(t *testing.T) {
+	var gs, want Set
+	gs.Index("the cat", "the dog", "the cow", "a dog")
+	gs.Remove("the cow")
+	gs.Update("the cat", "the catfish")
+	want.Index("the catfish", "the dog", "a dog")
+
+	if fmt.Sprint(gs.ls, gs.nt) != fmt.Sprint(want.ls, want.nt) {
+		t.Fatalf("have %v %v, want %v %v", gs.ls, gs.nt, want.ls, want.nt)
+	}
+
+	var buf bytes.Buffer
+	gs.WriteTo(&buf)
+	var rs Set
+	rs.ReadFrom(&buf)
+	if fmt.Sprint(rs.ls, rs.nt) != fmt.Sprint(want.ls, want.nt) {
+		t.Fatalf("ReadFrom have %v %v, want %v %v", rs.ls, rs.nt, want.ls, want.nt)
+	}
+}
+
+func TestTop(t *testing.T) {
+	m := []string{"a", "b", "c", "d"}
+	u := []float64{0.5, 1, 0.5, 0.75}
+	m, u = Top(m, u, 3)
+	if have, want := fmt.Sprint(m, u), "[b d a] [1 0.75 0.5]"; have != want {
+		t.Fatalf("have %v, want %v", have, want)
+	}
+	m, u = Top(m, u, 0)
+	if have, want := fmt.Sprint(m, u), "[b d a] [1 0.75 0.5]"; have != want {
+		t.Fatalf("have %v, want %v", have, want)
+	}
+}
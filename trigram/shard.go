@@ -0,0 +1,219 @@
+package trigram
+
+import (
+	"hash/fnv"
+	"runtime"
+	"sort"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+
+	"dasa.cc/x/set"
+)
+
+// IndexParallel parses and stores trigrams for each s in xs as Index, parsing with up to n
+// goroutines or GOMAXPROCS if n is not positive; Mapping, Fields, Padding and Normalize
+// functions must be safe for concurrent use. Result is identical to that of Index.
+func (a *Set) IndexParallel(n int, xs ...string) {
+	a.init()
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	if n > len(xs) {
+		n = len(xs)
+	}
+	if n == 0 {
+		return
+	}
+
+	ss := make([]shard[string], n+1)
+	ss[0] = shard[string]{a.ks, a.vs}
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int, xs []string) {
+			defer wg.Done()
+			ss[i+1] = newShard(xs, a.Parse)
+		}(i, xs[i*len(xs)/n:(i+1)*len(xs)/n])
+	}
+	wg.Wait()
+	a.postings = merge(ss)
+}
+
+// shard of trigram keys and postings.
+type shard[T constraints.Ordered] struct {
+	ks set.Slice[string]
+	vs set.Chain[T]
+}
+
+// newShard returns shard of each x in xs with trigrams given by parse.
+func newShard[T constraints.Ordered](xs []T, parse func(T) []string) shard[T] {
+	m := make(map[string][]T)
+	for _, x := range xs {
+		for _, t := range parse(x) {
+			m[t] = append(m[t], x)
+		}
+	}
+	sh := shard[T]{ks: make(set.Slice[string], 0, len(m))}
+	for t := range m {
+		sh.ks = append(sh.ks, t)
+	}
+	slices.Sort(sh.ks)
+	sh.vs = make(set.Chain[T], len(sh.ks))
+	for i, t := range sh.ks {
+		p := m[t]
+		set.Filter(&p)
+		sh.vs[i] = p
+	}
+	return sh
+}
+
+// merge returns postings of the union of ss, counting trigrams of each value.
+func merge[T constraints.Ordered](ss []shard[T]) postings[T] {
+	var a postings[T]
+	for _, sh := range ss {
+		a.ks = set.Union(a.ks, sh.ks)
+	}
+	a.vs = make(set.Chain[T], len(a.ks))
+	at := make([]int, len(ss))
+	for i, k := range a.ks {
+		for j, sh := range ss {
+			if at[j] < len(sh.ks) && sh.ks[at[j]] == k {
+				if a.vs[i] == nil {
+					a.vs[i] = append(set.Slice[T](nil), sh.vs[at[j]]...)
+				} else {
+					a.vs[i].Union(sh.vs[at[j]])
+				}
+				at[j]++
+			}
+		}
+	}
+
+	counts := make(map[T]int)
+	for _, p := range a.vs {
+		for _, v := range p {
+			counts[v]++
+		}
+		a.nt += len(p)
+	}
+	vals := make([]T, 0, len(counts))
+	for v := range counts {
+		vals = append(vals, v)
+	}
+	slices.Sort(vals)
+	for _, v := range vals {
+		a.ls.Put(v, counts[v])
+	}
+	return a
+}
+
+// Shards of Set, each indexing a partition of values by hash, for indexing and matching
+// in parallel; zero value is valid and creates GOMAXPROCS shards on first call to Index.
+type Shards struct {
+	Parser
+
+	// Scorer used when calling Match
+	// or defaults to Unit if not set.
+	Scorer Scorer
+
+	sets []Set
+}
+
+// NewShards returns n empty shards or GOMAXPROCS if n is not positive.
+func NewShards(n int) *Shards {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	return &Shards{sets: make([]Set, n)}
+}
+
+// Index parses and stores trigrams for each s in xs, indexing each shard in parallel;
+// Parser functions must be safe for concurrent use.
+func (a *Shards) Index(xs ...string) {
+	a.init()
+	if a.sets == nil {
+		a.sets = make([]Set, runtime.GOMAXPROCS(0))
+	}
+	parts := make([][]string, len(a.sets))
+	for _, s := range xs {
+		i := a.shard(s)
+		parts[i] = append(parts[i], s)
+	}
+	var wg sync.WaitGroup
+	for i := range a.sets {
+		a.sets[i].setParser(a.Parser)
+		if len(parts[i]) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a.sets[i].Index(parts[i]...)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Remove s from its shard; returns true if s was indexed.
+func (a *Shards) Remove(s string) bool {
+	if len(a.sets) == 0 {
+		return false
+	}
+	return a.sets[a.shard(s)].Remove(s)
+}
+
+// Match indexed values for x that meet min threshold as Set.Match, matching each shard
+// in parallel; returns matches in ascending order and scores. Trigrams are weighted by
+// postings of all shards, so scores are identical to those of a single Set.
+func (a Shards) Match(x string, min float64) ([]string, []float64) {
+	q := a.Parse(x)
+	var st stats
+	for i, gs := range a.sets {
+		if i == 0 {
+			st = gs.stats(q)
+		} else {
+			st.add(gs.stats(q))
+		}
+	}
+
+	ms := make([][]string, len(a.sets))
+	us := make([][]float64, len(a.sets))
+	var wg sync.WaitGroup
+	for i := range a.sets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ms[i], us[i] = a.sets[i].matchStats(q, min, a.Scorer, st)
+		}(i)
+	}
+	wg.Wait()
+
+	var r byValue[string]
+	for i := range ms {
+		r.m = append(r.m, ms[i]...)
+		r.u = append(r.u, us[i]...)
+	}
+	sort.Sort(r)
+	return r.m, r.u
+}
+
+// shard returns index of shard for s.
+func (a Shards) shard(s string) int {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return int(h.Sum32() % uint32(len(a.sets)))
+}
+
+// byValue sorts matches and scores by ascending match.
+type byValue[T constraints.Ordered] struct {
+	m []T
+	u []float64
+}
+
+func (a byValue[T]) Len() int           { return len(a.m) }
+func (a byValue[T]) Less(i, j int) bool { return a.m[i] < a.m[j] }
+func (a byValue[T]) Swap(i, j int) {
+	a.m[i], a.m[j] = a.m[j], a.m[i]
+	a.u[i], a.u[j] = a.u[j], a.u[i]
+}
@@ -0,0 +1,76 @@
+package trigram
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIndexParallel(t *testing.T) {
+	// duplicates and values indexed before both must match sequential Index.
+	xs := append(append([]string(nil), words[:1500]...), words[:100]...)
+	for _, n := range []int{0, 1, 2, 3, 8} {
+		var want Set
+		want.Index(words[1500:]...)
+		want.Index(xs...)
+
+		var have Set
+		have.Index(words[1500:]...)
+		have.IndexParallel(n, xs...)
+
+		if fmt.Sprint(have.postings) != fmt.Sprint(want.postings) {
+			t.Fatalf("IndexParallel(%v) does not match Index", n)
+		}
+	}
+
+	var gs Set
+	gs.IndexParallel(4)
+	if len(gs.ks) != 0 || gs.Mapping == nil {
+		t.Fatalf("IndexParallel without values: have %+v", gs)
+	}
+}
+
+func TestShards(t *testing.T) {
+	for _, sc := range []Scorer{nil, IDF{}, BM25{}, Jaccard{}} {
+		var gs Set
+		gs.Scorer = sc
+		gs.Index(words...)
+
+		ss := NewShards(5)
+		ss.Scorer = sc
+		ss.Index(words...)
+
+		for _, x := range []string{"eta", "nothing", words[7], words[99][1:]} {
+			wm, wu := gs.Match(x, 0.2)
+			hm, hu := ss.Match(x, 0.2)
+			if fmt.Sprint(hm, hu) != fmt.Sprint(wm, wu) {
+				t.Fatalf("%T Match(%q)\nhave %v %v\nwant %v %v", sc, x, hm, hu, wm, wu)
+			}
+		}
+	}
+
+	var ss Shards
+	ss.Index("red fox", "brown dog", "lazy dog")
+	if len(ss.sets) == 0 {
+		t.Fatal("zero Shards did not create shards")
+	}
+	if !ss.Remove("brown dog") || ss.Remove("brown dog") {
+		t.Fatal("Remove reported wrong result")
+	}
+	if m, _ := ss.Match("dog", 1); fmt.Sprint(m) != "[lazy dog]" {
+		t.Fatalf("Match after Remove: have %v, want [lazy dog]", m)
+	}
+}
+
+func BenchmarkIndex(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var gs Set
+		gs.Index(words...)
+	}
+}
+
+func BenchmarkIndexParallel(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var gs Set
+		gs.IndexParallel(0, words...)
+	}
+}
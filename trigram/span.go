@@ -0,0 +1,106 @@
+package trigram
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Span of bytes [Start, End) in a string.
+type Span struct{ Start, End int }
+
+// Spans returns ascending, non-overlapping spans of s covered by grams of s that are in q,
+// a sorted slice of grams such as returned by Parse. Spans are of bytes in s as given,
+// covering runes removed or changed by Normalize and Mapping when within a covered gram.
+//
+// Normalize is applied to each segment of s beginning with a starter rune, as defined
+// by Unicode normalization, so that changes may be traced to bytes of s.
+func (p Parser) Spans(s string, q []string) []Span {
+	p.init()
+	lead, trail := p.Padding(p.N)
+
+	var spans []Span
+	var word []byte
+	var org []Span // origin of each byte of word
+
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		t := strings.Repeat("\x00", lead) + string(word) + strings.Repeat("\x00", trail)
+		if len(t) < p.N {
+			t += strings.Repeat("\x00", p.N-len(t))
+		}
+		for i := 0; i+p.N <= len(t); i++ {
+			g := t[i : i+p.N]
+			if j := sort.SearchStrings(q, g); j == len(q) || q[j] != g {
+				continue
+			}
+			// first and last bytes of gram that are of word and not padding.
+			lo, hi := i-lead, i+p.N-1-lead
+			if lo < 0 {
+				lo = 0
+			}
+			if hi >= len(word) {
+				hi = len(word) - 1
+			}
+			if lo > hi {
+				continue
+			}
+			x := Span{org[lo].Start, org[hi].End}
+			if n := len(spans); n > 0 && x.Start <= spans[n-1].End {
+				if x.End > spans[n-1].End {
+					spans[n-1].End = x.End
+				}
+			} else {
+				spans = append(spans, x)
+			}
+		}
+		word, org = word[:0], org[:0]
+	}
+
+	for i := 0; i < len(s); {
+		n := 0
+		if p.Normalize != nil {
+			n = norm.NFD.NextBoundaryInString(s[i:], true)
+		}
+		if n <= 0 {
+			_, n = utf8.DecodeRuneInString(s[i:])
+		}
+		seg := s[i : i+n]
+		if p.Normalize != nil {
+			seg = p.Normalize(seg)
+		}
+		for _, r := range seg {
+			if r = p.Mapping(r); r < 0 {
+				continue
+			}
+			if p.Fields(r) {
+				flush()
+				continue
+			}
+			k := len(word)
+			word = utf8.AppendRune(word, r)
+			for ; k < len(word); k++ {
+				org = append(org, Span{i, i + n})
+			}
+		}
+		i += n
+	}
+	flush()
+	return spans
+}
+
+// MatchSpans is Match also returning spans of each match covered by trigrams of x.
+func (a Set) MatchSpans(x string, min float64) ([]string, []float64, [][]Span) {
+	p := a.Parser()
+	q := p.Parse(x)
+	m, u := a.match(q, min, a.Scorer)
+	spans := make([][]Span, len(m))
+	for i, s := range m {
+		spans[i] = p.Spans(s, q)
+	}
+	return m, u, spans
+}
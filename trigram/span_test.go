@@ -0,0 +1,53 @@
+package trigram
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSpans(t *testing.T) {
+	tests := []struct {
+		p    Parser
+		s, x string
+		want []Span
+	}{
+		{Parser{}, "The Quick, brown", "quick", []Span{{4, 9}}},
+		{Parser{}, "the quick brown", "the quick brown", []Span{{0, 3}, {4, 9}, {10, 15}}},
+		{Parser{}, "fmt.Printf", "printf", []Span{{4, 10}}},
+		{Parser{}, "fmt.Printf", "fmtprint", []Span{{0, 9}}},
+		{Parser{}, "brown dog", "bog", []Span{{0, 1}, {7, 9}}},
+		{Parser{}, "lazy dog", "fox", nil},
+		{Parser{Normalize: Fold}, "Café au lait", "cafe", []Span{{0, 5}}},
+		{Parser{Normalize: Fold}, "Cafe\u0301 au lait", "cafe", []Span{{0, 6}}},
+		{Parser{Normalize: NFKC}, "a ﬁne day", "fine", []Span{{2, 7}}},
+		{Parser{N: 2, Padding: NoPadding}, "a bc", "abc", []Span{{2, 4}}},
+	}
+	for _, tt := range tests {
+		if have := tt.p.Spans(tt.s, tt.p.Parse(tt.x)); fmt.Sprint(have) != fmt.Sprint(tt.want) {
+			t.Errorf("Spans(%q) of %q: have %v, want %v", tt.s, tt.x, have, tt.want)
+		}
+	}
+
+	var p Parser
+	for _, s := range words[:200] {
+		if have := p.Spans(s, p.Parse(s)); fmt.Sprint(have) != fmt.Sprint([]Span{{0, len(s)}}) {
+			t.Fatalf("Spans(%q) of itself: have %v", s, have)
+		}
+	}
+}
+
+func TestMatchSpans(t *testing.T) {
+	var gs Set
+	gs.Index("fmt.Printf", "fmt.Sprintf", "fmt.Println")
+	m, u, spans := gs.MatchSpans("printf", 0.5)
+	wm, wu := gs.Match("printf", 0.5)
+	if fmt.Sprint(m, u) != fmt.Sprint(wm, wu) || len(spans) != len(m) {
+		t.Fatalf("have %v %v %v, want %v %v", m, u, spans, wm, wu)
+	}
+	want := map[string]string{"fmt.Printf": "[{4 10}]", "fmt.Sprintf": "[{5 11}]", "fmt.Println": "[{4 9}]"}
+	for i, s := range m {
+		if have := fmt.Sprint(spans[i]); have != want[s] {
+			t.Fatalf("spans of %q: have %v, want %v", s, have, want[s])
+		}
+	}
+}
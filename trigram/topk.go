@@ -0,0 +1,211 @@
+package trigram
+
+import (
+	"container/heap"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+
+	"dasa.cc/x/set"
+)
+
+// MatchTopK returns at most k indexed values for x with greatest scores, sorted by descending
+// score, ties in ascending order of value. Results equal those of Match with zero threshold
+// passed to Top, up to rounding of weighted scores, but trigrams are visited rarest first and
+// values that can no longer reach the k-th greatest score are pruned early. Scorer, if set,
+// must score no lower when shared trigrams increase and no higher when value trigrams increase.
+func (a Set) MatchTopK(x string, k int) ([]string, []float64) {
+	return a.topk(a.Parse(x), k, a.Scorer)
+}
+
+// MatchTopK returns at most k indexed IDs for x with greatest scores as Set.MatchTopK does.
+func (a IDSet) MatchTopK(x string, k int) ([]uint64, []float64) {
+	return a.topk(a.Parse(x), k, a.Scorer)
+}
+
+// topk values sharing trigrams with q; returns at most k matches by descending score.
+func (a postings[T]) topk(q []string, k int, sc Scorer) ([]T, []float64) {
+	if k <= 0 {
+		return nil, nil
+	}
+	if sc == nil {
+		sc = Unit{}
+	}
+
+	// postings of query trigrams found, rarest first.
+	type list struct {
+		i int
+		w float64
+	}
+	var ls []list
+	var qw, rw float64
+	for _, s := range q {
+		i := sort.SearchStrings(a.ks, s)
+		if i == len(a.ks) || a.ks[i] != s {
+			qw += sc.Weight(0, a.ls.Len())
+			continue
+		}
+		w := sc.Weight(len(a.vs[i]), a.ls.Len())
+		ls = append(ls, list{i, w})
+		qw, rw = qw+w, rw+w
+	}
+	fw := rw // weight sum of query trigrams found
+	sort.SliceStable(ls, func(i, j int) bool { return len(a.vs[ls[i].i]) < len(a.vs[ls[j].i]) })
+
+	var mean float64
+	if n := a.ls.Len(); n > 0 {
+		mean = float64(a.nt) / float64(n)
+	}
+	score := func(c cand[T], rw float64, rn int) float64 {
+		return sc.Score(Overlap{
+			Shared: c.u + rw, Query: qw,
+			NShared: c.n + rn, NQuery: len(q), NValue: c.nv,
+			NMean: mean,
+		})
+	}
+
+	var cs, next []cand[T]
+	var h minScore[T]
+	admit := true
+	for j, l := range ls {
+		p := a.vs[l.i]
+		if admit {
+			// merge postings into values seen.
+			if n := len(cs) + len(p); cap(next) < n {
+				next = make([]cand[T], 0, n)
+			}
+			next = next[:0]
+			var i int
+			for _, c := range cs {
+				for ; i < len(p) && p[i] < c.x; i++ {
+					next = append(next, cand[T]{p[i], l.w, 1, -1})
+				}
+				if i < len(p) && p[i] == c.x {
+					c.u, c.n, i = c.u+l.w, c.n+1, i+1
+				}
+				next = append(next, c)
+			}
+			for ; i < len(p); i++ {
+				next = append(next, cand[T]{p[i], l.w, 1, -1})
+			}
+			cs, next = next, cs
+		} else {
+			var off int
+			for i, c := range cs {
+				if off = gallop(p, off, c.x); off < len(p) && p[off] == c.x {
+					cs[i].u, cs[i].n = c.u+l.w, c.n+1
+				}
+			}
+		}
+		rw -= l.w
+		rn := len(ls) - j - 1
+		if rn == 0 || len(cs) < k {
+			continue
+		}
+
+		// skip threshold while unseen values could outscore any value seen.
+		if admit && score(cand[T]{nv: rn}, rw, rn) >= score(cand[T]{u: fw - rw, n: j + 1, nv: j + 1}, 0, 0) {
+			continue
+		}
+
+		// threshold is the k-th greatest score of values seen, a lower bound for final results.
+		a.fill(cs)
+		h = h[:0]
+		for _, c := range cs {
+			h.push(c.x, score(c, 0, 0), k)
+		}
+		min := h[0].u - 1e-9
+
+		// unseen values share at most remaining trigrams and have at least as many.
+		if admit {
+			admit = score(cand[T]{nv: rn}, rw, rn) >= min
+		}
+
+		// drop values seen that can't reach threshold with all remaining trigrams.
+		fs := cs[:0]
+		for _, c := range cs {
+			if score(c, rw, rn) >= min {
+				fs = append(fs, c)
+			}
+		}
+		cs = fs
+	}
+
+	a.fill(cs)
+	h = h[:0]
+	for _, c := range cs {
+		h.push(c.x, score(c, 0, 0), k)
+	}
+	m, u := make([]T, len(h)), make([]float64, len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		e := heap.Pop(&h).(scored[T])
+		m[i], u[i] = e.x, e.u
+	}
+	return m, u
+}
+
+// fill unknown trigram counts of values seen, given in ascending order.
+func (a postings[T]) fill(cs []cand[T]) {
+	ks, off := a.ls.Keys(), 0
+	for i, c := range cs {
+		if c.nv < 0 {
+			off = gallop(ks, off, c.x)
+			_, cs[i].nv = a.ls.At(off)
+		}
+	}
+}
+
+// gallop returns least index of a, no less than off, with value no less than x,
+// searching exponentially farther from off.
+func gallop[T constraints.Ordered](a set.Slice[T], off int, x T) int {
+	n := 1
+	for off+n < len(a) && a[off+n] < x {
+		n *= 2
+	}
+	hi := off + n + 1
+	if hi > len(a) {
+		hi = len(a)
+	}
+	return off + a[off:hi].Rank(x)
+}
+
+// cand is a value seen with weight sum and count of shared trigrams, and its trigram count
+// or -1 if unknown.
+type cand[T any] struct {
+	x     T
+	u     float64
+	n, nv int
+}
+
+type scored[T any] struct {
+	x T
+	u float64
+}
+
+// minScore is a heap of least score, ties by greatest value, at root.
+type minScore[T constraints.Ordered] []scored[T]
+
+// push x with score u, keeping at most k values of greatest score.
+func (h *minScore[T]) push(x T, u float64, k int) {
+	e := scored[T]{x, u}
+	if len(*h) < k {
+		heap.Push(h, e)
+	} else if h.less(e, (*h)[0]) {
+		(*h)[0] = e
+		heap.Fix(h, 0)
+	}
+}
+
+// less reports whether a ranks before b.
+func (minScore[T]) less(a, b scored[T]) bool { return a.u > b.u || (a.u == b.u && a.x < b.x) }
+
+func (h minScore[T]) Len() int            { return len(h) }
+func (h minScore[T]) Less(i, j int) bool  { return h.less(h[j], h[i]) }
+func (h minScore[T]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minScore[T]) Push(x interface{}) { *h = append(*h, x.(scored[T])) }
+func (h *minScore[T]) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
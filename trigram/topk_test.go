@@ -0,0 +1,87 @@
+package trigram
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+var words []string
+
+func init() {
+	// letters of zipf distribution produce both common and rare trigrams.
+	const letters = "etaoinshrdlcumwfgypbvkjxqz"
+	rnd := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rnd, 1.1, 2, uint64(len(letters)-1))
+	for i := 0; i < 2000; i++ {
+		var b strings.Builder
+		for n := 3 + rnd.Intn(12); n > 0; n-- {
+			b.WriteByte(letters[zipf.Uint64()])
+		}
+		words = append(words, b.String())
+	}
+}
+
+func TestMatchTopK(t *testing.T) {
+	scorers := []Scorer{nil, IDF{}, BM25{}, Jaccard{}, Dice{}}
+	for _, sc := range scorers {
+		var gs Set
+		gs.Scorer = sc
+		gs.Index(words...)
+		for n := 0; n < 50; n++ {
+			x := words[rand.Intn(len(words))]
+			x = x[rand.Intn(len(x)/2):]
+			for _, k := range []int{1, 5, 20, 10000} {
+				wm, wu := gs.Match(x, 0)
+				wm, wu = Top(wm, wu, k)
+				hm, hu := gs.MatchTopK(x, k)
+				if fmt.Sprint(hm) != fmt.Sprint(wm) {
+					t.Fatalf("%T MatchTopK(%q, %v)\nhave %v %v\nwant %v %v", sc, x, k, hm, hu, wm, wu)
+				}
+				// weights are summed in a different order.
+				for i := range hu {
+					if math.Abs(hu[i]-wu[i]) > 1e-12 {
+						t.Fatalf("%T MatchTopK(%q, %v) score of %q: have %v, want %v", sc, x, k, hm[i], hu[i], wu[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMatchTopKEmpty(t *testing.T) {
+	var gs Set
+	if m, u := gs.MatchTopK("abc", 10); len(m) != 0 || len(u) != 0 {
+		t.Fatalf("have %v %v, want none", m, u)
+	}
+	gs.Index("abc")
+	if m, u := gs.MatchTopK("abc", 0); len(m) != 0 || len(u) != 0 {
+		t.Fatalf("have %v %v, want none", m, u)
+	}
+	if m, _ := gs.MatchTopK("xyz", 10); len(m) != 0 {
+		t.Fatalf("have %v, want none", m)
+	}
+}
+
+func BenchmarkMatch_Top10(b *testing.B) {
+	var gs Set
+	gs.Index(words...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		m, u := gs.Match(words[0], 0)
+		Top(m, u, 10)
+	}
+}
+
+func BenchmarkMatchTopK_10(b *testing.B) {
+	var gs Set
+	gs.Index(words...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		gs.MatchTopK(words[0], 10)
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"strings"
 	"unicode"
 
+	"golang.org/x/exp/constraints"
+
 	"dasa.cc/x/set"
 )
 
-// Set indexer; zero value is valid.
-type Set struct {
+// Parser of trigrams, or n-grams if N is set; zero value is valid.
+type Parser struct {
 	// Mapping function used when calling Parse
 	// or defaults to IsSpaceDigitLetterToLower if not set.
 	Mapping func(rune) rune
@@ -19,50 +21,278 @@ type Set struct {
 	// or defaults to unicode.IsSpace if not set.
 	Fields func(rune) bool
 
-	ks set.Slice[string]
-	vs set.Chain[string]
+	// N is byte length of grams used when calling Parse
+	// or defaults to 3 if not positive.
+	N int
+
+	// Padding function used when calling Parse
+	// or defaults to DefaultPadding if not set.
+	Padding func(n int) (lead, trail int)
+
+	// Normalize function used when calling Parse, applied to s before Mapping,
+	// or s is not normalized if not set.
+	Normalize func(string) string
 }
 
-// Index parses and stores trigrams for each s in xs. Panics if nil.
-func (a *Set) Index(xs ...string) {
-	if a.Mapping == nil {
-		a.Mapping = IsSpaceDigitLetterToLower
+// Parse returns a slice of grams for s with package ParseN, using defaults for fields not set.
+func (p Parser) Parse(s string) []string {
+	p.init()
+	if p.Normalize != nil {
+		s = p.Normalize(s)
+	}
+	lead, trail := p.Padding(p.N)
+	return ParseN(s, p.N, lead, trail, p.Mapping, p.Fields)
+}
+
+// init sets default functions if nil and default N if not positive.
+func (p *Parser) init() {
+	if p.Mapping == nil {
+		p.Mapping = IsSpaceDigitLetterToLower
+	}
+	if p.Fields == nil {
+		p.Fields = unicode.IsSpace
 	}
-	if a.Fields == nil {
-		a.Fields = unicode.IsSpace
+	if p.N <= 0 {
+		p.N = 3
 	}
+	if p.Padding == nil {
+		p.Padding = DefaultPadding
+	}
+}
+
+// Set indexer; zero value is valid.
+type Set struct {
+	// Mapping, Fields, N, Padding and Normalize used when calling Parse as those of Parser.
+	Mapping   func(rune) rune
+	Fields    func(rune) bool
+	N         int
+	Padding   func(n int) (lead, trail int)
+	Normalize func(string) string
+
+	// Scorer used when calling Match
+	// or defaults to Unit if not set.
+	Scorer Scorer
+
+	postings[string]
+}
+
+// Parser returns Parser of fields of a.
+func (a Set) Parser() Parser {
+	return Parser{Mapping: a.Mapping, Fields: a.Fields, N: a.N, Padding: a.Padding, Normalize: a.Normalize}
+}
+
+// Parse returns a slice of grams for s as Parser.Parse.
+func (a Set) Parse(s string) []string { return a.Parser().Parse(s) }
+
+// init sets default functions if nil and default N if not positive.
+func (a *Set) init() {
+	p := a.Parser()
+	p.init()
+	a.setParser(p)
+}
+
+// setParser sets fields of a to those of p.
+func (a *Set) setParser(p Parser) {
+	a.Mapping, a.Fields, a.N, a.Padding, a.Normalize = p.Mapping, p.Fields, p.N, p.Padding, p.Normalize
+}
+
+// Index parses and stores trigrams for each s in xs. Panics if nil.
+func (a *Set) Index(xs ...string) {
+	a.init()
 	for _, s := range xs {
-		for _, t := range Parse(s, a.Mapping, a.Fields) {
-			i, ok := a.ks.Insert(t)
-			a.vs.Upsert(s, i, ok)
-		}
+		a.insert(s, a.Parse(s))
 	}
 }
 
-// Match indexed values for x that meet min threshold; returns matches and unit scores.
+// Remove s from postings of each of its trigrams, dropping trigrams left without values;
+// returns true if s was indexed.
+func (a *Set) Remove(s string) bool {
+	return a.remove(s, a.Parse(s))
+}
+
+// Update removes old and indexes new; returns true if old was indexed.
+func (a *Set) Update(old, new string) bool {
+	ok := a.Remove(old)
+	a.Index(new)
+	return ok
+}
+
+// Match indexed values for x that meet min threshold; returns matches and scores.
+// Scores are unit scores, ratio of trigrams of x matched, unless Scorer is set.
 func (a Set) Match(x string, min float64) ([]string, []float64) {
-	var p set.Slice[string]
-	var u []float64
-
-	q := Parse(x, a.Mapping, a.Fields)
-	for _, s := range q {
-		if i := sort.SearchStrings(a.ks, s); i < len(a.ks) && a.ks[i] == s {
-			for _, t := range a.vs[i] {
-				j, ok := 0, false
-				if j, ok = p.Insert(t); ok {
-					u = append(u, 0)
-					copy(u[j+1:], u[j:])
-					u[j] = 0
-				}
-				u[j]++
+	return a.match(a.Parse(x), min, a.Scorer)
+}
+
+// postings of values for each trigram key; zero value is valid.
+type postings[T constraints.Ordered] struct {
+	ks set.Slice[string]
+	vs set.Chain[T]
+
+	ls set.Map[T, int] // trigram count of each value
+	nt int             // trigram count of all values
+}
+
+// insert x into postings of each trigram in q.
+func (a *postings[T]) insert(x T, q []string) {
+	var n int
+	for _, t := range q {
+		i, ok := a.ks.Insert(t)
+		if _, ok = a.vs.Upsert(x, i, ok); ok {
+			n++
+		}
+	}
+	if n > 0 {
+		a.count(x, n)
+	}
+}
+
+// count adds d to trigram count of x, deleting x at zero.
+func (a *postings[T]) count(x T, d int) {
+	n, _ := a.ls.Get(x)
+	if n += d; n == 0 {
+		a.ls.Delete(x)
+	} else {
+		a.ls.Put(x, n)
+	}
+	a.nt += d
+}
+
+// remove x from postings of each trigram in q, dropping trigrams left without values;
+// returns true if any removed.
+func (a *postings[T]) remove(x T, q []string) (ok bool) {
+	for _, t := range q {
+		if i := a.ks.Index(t); i != -1 {
+			if _, removed := a.vs[i].Remove(x); removed {
+				ok = true
+				a.count(x, -1)
+				a.drop(i)
 			}
 		}
 	}
+	return ok
+}
+
+// removeAll x from postings of every trigram, dropping trigrams left without values;
+// returns true if any removed.
+func (a *postings[T]) removeAll(x T) (ok bool) {
+	for i := len(a.ks) - 1; i >= 0; i-- {
+		if _, removed := a.vs[i].Remove(x); removed {
+			ok = true
+			a.count(x, -1)
+			a.drop(i)
+		}
+	}
+	return ok
+}
+
+// drop trigram at i if without values.
+func (a *postings[T]) drop(i int) {
+	if len(a.vs[i]) == 0 {
+		a.ks = append(a.ks[:i], a.ks[i+1:]...)
+		copy(a.vs[i:], a.vs[i+1:])
+		a.vs[len(a.vs)-1] = nil
+		a.vs = a.vs[:len(a.vs)-1]
+	}
+}
+
+// match values sharing trigrams with q that meet min threshold; returns matches and scores.
+func (a postings[T]) match(q []string, min float64, sc Scorer) ([]T, []float64) {
+	return a.matchStats(q, min, sc, a.stats(q))
+}
+
+// matchStats is match weighting trigrams of q by st, which may be of more postings than a.
+func (a postings[T]) matchStats(q []string, min float64, sc Scorer, st stats) ([]T, []float64) {
+	if sc == nil {
+		sc = Unit{}
+	}
+	var t tally[T]
+	var qw float64
+	for j, s := range q {
+		w := sc.Weight(st.df[j], st.n)
+		qw += w
+		i := sort.SearchStrings(a.ks, s)
+		if i == len(a.ks) || a.ks[i] != s {
+			continue
+		}
+		for _, v := range a.vs[i] {
+			t.add(v, w)
+		}
+	}
+	mean := st.mean()
+	return t.filter(min, func(i int) float64 {
+		return sc.Score(a.overlap(t.p[i], t.u[i], qw, t.n[i], len(q), mean))
+	})
+}
+
+// stats of postings for weighting trigrams of a query.
+type stats struct {
+	df    []int // value count of each query trigram
+	n, nt int   // count of values and trigram count of all values
+}
+
+// stats returns stats of a for trigrams of q.
+func (a postings[T]) stats(q []string) stats {
+	st := stats{df: make([]int, len(q)), n: a.ls.Len(), nt: a.nt}
+	for j, s := range q {
+		if i := a.ks.Index(s); i != -1 {
+			st.df[j] = len(a.vs[i])
+		}
+	}
+	return st
+}
+
+// add stats of b for the same query to st.
+func (st *stats) add(b stats) {
+	for j := range st.df {
+		st.df[j] += b.df[j]
+	}
+	st.n += b.n
+	st.nt += b.nt
+}
+
+// mean trigram count of values.
+func (st stats) mean() float64 {
+	if st.n == 0 {
+		return 0
+	}
+	return float64(st.nt) / float64(st.n)
+}
+
+// overlap of x with a query given weight sums and counts of shared and query trigrams,
+// and mean trigram count of values.
+func (a postings[T]) overlap(x T, shared, query float64, nshared, nquery int, mean float64) Overlap {
+	o := Overlap{Shared: shared, Query: query, NShared: nshared, NQuery: nquery, NMean: mean}
+	o.NValue, _ = a.ls.Get(x)
+	return o
+}
+
+// tally sums weights and counts occurrences of distinct values.
+type tally[T constraints.Ordered] struct {
+	p set.Slice[T]
+	u []float64
+	n []int
+}
 
-	fp, fu := p[:0], u[:0]
-	for i, s := range p {
-		if w := u[i] / float64(len(q)); min <= w {
-			fp, fu = append(fp, s), append(fu, w)
+func (t *tally[T]) add(x T, w float64) {
+	j, ok := t.p.Insert(x)
+	if ok {
+		t.u = append(t.u, 0)
+		copy(t.u[j+1:], t.u[j:])
+		t.u[j] = 0
+		t.n = append(t.n, 0)
+		copy(t.n[j+1:], t.n[j:])
+		t.n[j] = 0
+	}
+	t.u[j] += w
+	t.n[j]++
+}
+
+// filter values in place with scores given by fn of value index that meet min threshold.
+func (t *tally[T]) filter(min float64, fn func(i int) float64) ([]T, []float64) {
+	fp, fu := t.p[:0], t.u[:0]
+	for i, x := range t.p {
+		if w := fn(i); min <= w {
+			fp, fu = append(fp, x), append(fu, w)
 		}
 	}
 	return fp, fu
@@ -71,16 +301,41 @@ func (a Set) Match(x string, min float64) ([]string, []float64) {
 // Parse returns a slice of trigrams for s after modifying characters according to
 // the mapping function followed by word splitting according to fields function.
 func Parse(s string, mapping func(rune) rune, fields func(rune) bool) []string {
+	return ParseN(s, 3, 2, 1, mapping, fields)
+}
+
+// ParseN returns a slice of n-grams for s as Parse, with each word padded by lead and trail
+// count of zero bytes. Padded words shorter than n are extended with zero bytes to a single gram.
+func ParseN(s string, n, lead, trail int, mapping func(rune) rune, fields func(rune) bool) []string {
 	var p set.Slice[string]
 	for _, t := range strings.FieldsFunc(strings.Map(mapping, s), fields) {
-		t = "\x00\x00" + t + "\x00"
-		for i := 0; i <= len(t)-3; i++ {
-			p.Insert(t[i : i+3])
+		t = strings.Repeat("\x00", lead) + t + strings.Repeat("\x00", trail)
+		if len(t) < n {
+			t += strings.Repeat("\x00", n-len(t))
+		}
+		for i := 0; i <= len(t)-n; i++ {
+			p.Insert(t[i : i+n])
 		}
 	}
 	return p
 }
 
+// DefaultPadding returns n-1 leading and one trailing zero bytes, so every prefix of a word
+// shorter than n is a gram; returns zero for both if n is one.
+func DefaultPadding(n int) (lead, trail int) {
+	if n <= 1 {
+		return 0, 0
+	}
+	return n - 1, 1
+}
+
+// NoPadding returns zero leading and trailing bytes.
+func NoPadding(n int) (lead, trail int) { return 0, 0 }
+
+// FullPadding returns n-1 leading and trailing zero bytes, so every prefix and suffix
+// of a word shorter than n is a gram.
+func FullPadding(n int) (lead, trail int) { return n - 1, n - 1 }
+
 // IsSpaceDigitLetterToLower reports whether rune is a letter, digit, or space character
 // as defined by Unicode's White Space property and maps rune to lower case.
 func IsSpaceDigitLetterToLower(r rune) rune {
@@ -1,7 +1,10 @@
 package trigram
 
 import (
+	"fmt"
+	"math/rand"
 	"testing"
+	"unicode"
 )
 
 var big []string
@@ -37,6 +40,59 @@ func TestSet(t *testing.T) {
 	}
 }
 
+func TestSetRemove(t *testing.T) {
+	terms := []string{"the quick", "red fox", "jumps over", "the lazy", "brown dog"}
+
+	var gs Set
+	live := make(map[string]bool)
+
+	// check gs against a Set built from scratch with live terms.
+	check := func(step string) {
+		var want Set
+		for _, s := range terms {
+			if live[s] {
+				want.Index(s)
+			}
+		}
+		if fmt.Sprint(want.ks, want.vs) != fmt.Sprint(gs.ks, gs.vs) {
+			t.Fatalf("%s\nhave %q %q\nwant %q %q", step, gs.ks, gs.vs, want.ks, want.vs)
+		}
+	}
+
+	for n := 0; n < 200; n++ {
+		s := terms[rand.Intn(len(terms))]
+		if rand.Intn(2) == 0 {
+			gs.Index(s)
+			live[s] = true
+			check("Index " + s)
+		} else {
+			if ok := gs.Remove(s); ok != live[s] {
+				t.Fatalf("Remove(%q) reported %v, want %v", s, ok, live[s])
+			}
+			delete(live, s)
+			check("Remove " + s)
+		}
+	}
+
+	for s := range live {
+		gs.Remove(s)
+	}
+	if len(gs.ks) != 0 || len(gs.vs) != 0 {
+		t.Fatalf("Unexpected lengths after removing all, have keys %v and values %v", len(gs.ks), len(gs.vs))
+	}
+
+	gs.Index("brown dog")
+	if !gs.Update("brown dog", "brown fog") || gs.Update("brown dog", "red fog") {
+		t.Fatal("Update reported wrong result")
+	}
+	if m, _ := gs.Match("dog", 0.5); len(m) != 0 {
+		t.Fatalf("Match after Update returned old value: %q", m)
+	}
+	if m, _ := gs.Match("fog", 1); fmt.Sprint(m) != "[brown fog red fog]" {
+		t.Fatalf("Match after Update: have %q", m)
+	}
+}
+
 func BenchmarkSet(b *testing.B) {
 	b.ReportAllocs()
 	for n := 0; n < b.N; n++ {
@@ -48,3 +104,29 @@ func BenchmarkSet(b *testing.B) {
 		}
 	}
 }
+
+func TestParseN(t *testing.T) {
+	tests := []struct {
+		p    Parser
+		s    string
+		want []string
+	}{
+		{Parser{}, "ab cde", Parse("ab cde", IsSpaceDigitLetterToLower, unicode.IsSpace)},
+		{Parser{N: 2}, "Abc", []string{"\x00a", "ab", "bc", "c\x00"}},
+		{Parser{N: 4}, "ab", []string{"\x00\x00\x00a", "\x00\x00ab", "\x00ab\x00"}},
+		{Parser{N: 4, Padding: NoPadding}, "ab abcde", []string{"ab\x00\x00", "abcd", "bcde"}},
+		{Parser{N: 2, Padding: FullPadding}, "ab", []string{"\x00a", "ab", "b\x00"}},
+		{Parser{N: 1}, "abca", []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		if have := tt.p.Parse(tt.s); fmt.Sprintf("%q", have) != fmt.Sprintf("%q", tt.want) {
+			t.Errorf("Parser{N: %v}.Parse(%q): have %q, want %q", tt.p.N, tt.s, have, tt.want)
+		}
+	}
+
+	gs := Set{N: 2}
+	gs.Index("go", "fmt.Printf", "os")
+	if m, _ := gs.Match("go", 1); fmt.Sprint(m) != "[go]" {
+		t.Fatalf("Match with bigrams: have %v, want [go]", m)
+	}
+}